@@ -2,6 +2,7 @@ package ast
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/ah-naf/borno/token"
 	"golang.org/x/text/unicode/norm"
@@ -44,10 +45,6 @@ func (l *Literal) String() string {
 	if l.Value == nil {
 		return "nil"
 	}
-	
-	if runes, ok := l.Value.([]rune); ok {
-        return norm.NFC.String(string(runes))
-    }
     return norm.NFC.String(fmt.Sprintf("%v", l.Value))
 }
 
@@ -75,6 +72,7 @@ type Logical struct {
 	Left     Expr
 	Operator token.Token
 	Right    Expr
+	Line     int
 }
 
 func (l *Logical) String() string {
@@ -86,6 +84,7 @@ type Call struct {
 	Callee    Expr        // The expression that evaluates to the function (callee).
 	Paren     token.Token // The opening parenthesis of the call (for error reporting).
 	Arguments []Expr      // The list of arguments passed to the function.
+	Line      int         // The line of the opening parenthesis.
 }
 
 func (c *Call) String() string {
@@ -102,12 +101,41 @@ func (c *Call) String() string {
 type Return struct {
 	Keyword token.Token
 	Value   Expr
+	Line    int
 }
 
 func (r *Return) String() string {
+	if r.Value == nil {
+		return "return"
+	}
 	return "return " + r.Value.String()
 }
 
+// FunctionExpr is an anonymous function used as a value, such as
+// ফাংশন(x) { ফেরত x * 2; }. Its declaration has no name. In the arrow form,
+// ফাংশন(x) => x * 2, the body is a single return of the expression after the
+// arrow.
+type FunctionExpr struct {
+	Declaration *FunctionStmt
+	Arrow       bool
+	Line        int
+}
+
+func (f *FunctionExpr) String() string {
+	params := make([]string, len(f.Declaration.Params))
+	for i, param := range f.Declaration.Params {
+		params[i] = param.Lexeme
+	}
+	if f.Arrow {
+		return fmt.Sprintf("fun(%s) => %s", strings.Join(params, ", "), f.Declaration.Body[0].(*Return).Value.String())
+	}
+	body := ""
+	for _, stmt := range f.Declaration.Body {
+		body += stmt.String() + "\n"
+	}
+	return fmt.Sprintf("fun(%s) {\n%s}", strings.Join(params, ", "), body)
+}
+
 // ArrayLiteral represents an array literal in the source code.
 type ArrayLiteral struct {
 	Elements []Expr
@@ -126,20 +154,26 @@ func (a *ArrayLiteral) String() string {
 	return val
 }
 
-// ArrayAccess represents accessing an element from an array.
+// ArrayAccess represents accessing an element from an array. An Optional
+// access (arr?.[i]) evaluates to nil when the array is nil.
 type ArrayAccess struct {
-	Array Expr
-	Index Expr
-	Line  int
+	Array    Expr
+	Index    Expr
+	Optional bool
+	Line     int
 }
 
 func (a *ArrayAccess) String() string {
+	if a.Optional {
+		return fmt.Sprintf("%v?.[%v]", a.Array, a.Index)
+	}
 	return fmt.Sprintf("%v[%v]", a.Array, a.Index)
 }
 
 // ObjectLiteral represents an object literal in the source code.
 type ObjectLiteral struct {
 	Properties map[string]Expr
+	Line       int
 }
 
 func (o *ObjectLiteral) String() string {
@@ -159,9 +193,24 @@ func (o *ObjectLiteral) String() string {
 type PropertyAccess struct {
 	Object   Expr
 	Property token.Token
+	Optional bool
 	Line     int
 }
 
 func (p *PropertyAccess) String() string {
+	if p.Optional {
+		return fmt.Sprintf("%s?.%s", p.Object.String(), p.Property.Lexeme)
+	}
 	return fmt.Sprintf("%s.%s", p.Object.String(), p.Property.Lexeme)
 }
+
+// Spread represents an array expanded in place with '...' inside a call's
+// arguments or an array literal.
+type Spread struct {
+	Expression Expr
+	Line       int
+}
+
+func (s *Spread) String() string {
+	return "..." + s.Expression.String()
+}
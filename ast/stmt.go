@@ -2,6 +2,7 @@ package ast
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/ah-naf/borno/token"
 )
@@ -12,6 +13,7 @@ type Stmt interface {
 
 type ExpressionStatement struct {
 	Expression Expr
+	Line       int
 }
 
 // String method for ExpressionStatement
@@ -20,33 +22,56 @@ func (e *ExpressionStatement) String() string {
 }
 
 type PrintStatement struct {
-	Expression Expr
+	Expressions []Expr
+	Line        int
 }
 
 // String method for PrintStatement
 func (p *PrintStatement) String() string {
-	return fmt.Sprintf("(print %s)", p.Expression.String()) // Return string representation of print statement
+	values := ""
+	for i, expr := range p.Expressions {
+		if i != 0 {
+			values += ", "
+		}
+		values += expr.String()
+	}
+	return fmt.Sprintf("(print %s)", values) // Return string representation of print statement
 }
 
 type VarStmt struct {
 	Name        token.Token
 	Initializer Expr
+	// Pattern holds the names of an array pattern such as [a, _, b], which
+	// binds each name to the element of Initializer at the same position.
+	// Name is unused when Pattern is set.
+	Pattern []token.Token
 	// VarUsed		bool
 	Line int
 }
 
 func (v *VarStmt) String() string {
+	if v.Pattern != nil {
+		names := make([]string, len(v.Pattern))
+		for i, name := range v.Pattern {
+			names[i] = name.Lexeme
+		}
+		return fmt.Sprintf("var [%s] = %v", strings.Join(names, ", "), v.Initializer)
+	}
+	if v.Initializer == nil {
+		return fmt.Sprintf("var %s", v.Name.Lexeme)
+	}
 	return fmt.Sprintf("var %s = %v", v.Name.Lexeme, v.Initializer)
 }
 
 type VarListStmt struct {
 	Declarations []VarStmt
+	Line         int
 }
 
 func (v *VarListStmt) String() string {
 	output := ""
 	for _, varStmt := range v.Declarations {
-		output += fmt.Sprintf("var %s = %v\n", varStmt.Name.Lexeme, varStmt.Initializer)
+		output += varStmt.String() + "\n"
 	}
 	return output
 }
@@ -63,6 +88,7 @@ func (a *AssignmentStmt) String() string {
 
 type BlockStmt struct {
 	Block []Stmt
+	Line  int
 }
 
 func (b *BlockStmt) String() string {
@@ -78,6 +104,7 @@ type IfStmt struct {
 	Condition  Expr
 	ThenBranch Stmt
 	ElseBranch Stmt
+	Line       int
 }
 
 func (i *IfStmt) String() string {
@@ -94,6 +121,7 @@ func (i *IfStmt) String() string {
 type While struct {
 	Condition Expr
 	Body      Stmt
+	Line      int
 }
 
 func (w *While) String() string {
@@ -103,10 +131,13 @@ func (w *While) String() string {
 }
 
 type ForStmt struct {
-	Condition   Expr
-	Increment   Expr
+	Condition Expr
+	// Increments are the comma-separated expressions after the condition,
+	// evaluated in order after each iteration
+	Increments  []Expr
 	Initializer Stmt
 	Body        Stmt
+	Line        int
 }
 
 func (f *ForStmt) String() string {
@@ -120,10 +151,11 @@ func (f *ForStmt) String() string {
 		conditionStr = f.Condition.String()
 	}
 
-	incrementStr := ""
-	if f.Increment != nil {
-		incrementStr = f.Increment.String()
+	increments := make([]string, len(f.Increments))
+	for i, increment := range f.Increments {
+		increments[i] = increment.String()
 	}
+	incrementStr := strings.Join(increments, ", ")
 
 	bodyStr := ""
 	if f.Body != nil {
@@ -133,6 +165,107 @@ func (f *ForStmt) String() string {
 	return fmt.Sprintf("for (%v; %v; %v) %v", initializerStr, conditionStr, incrementStr, bodyStr)
 }
 
+// ForEachStmt binds Variable to each element of an array, each key of an
+// object, or each character of a string in turn and runs Body for it.
+type ForEachStmt struct {
+	Variable token.Token
+	Iterable Expr
+	Body     Stmt
+	Line     int
+}
+
+func (f *ForEachStmt) String() string {
+	return fmt.Sprintf("foreach (%s in %s) %s", f.Variable.Lexeme, f.Iterable, f.Body)
+}
+
+// SwitchStmt runs the body of the first case whose value equals the
+// discriminant, or the default body when no case matches. Cases do not fall
+// through to the next one.
+type SwitchStmt struct {
+	Discriminant Expr
+	Cases        []SwitchCase
+	Default      []Stmt
+	HasDefault   bool
+	Line         int
+}
+
+// SwitchCase matches when the discriminant equals any of its Values. A value
+// may be a CaseRange.
+type SwitchCase struct {
+	Values []Expr
+	Body   []Stmt
+	Line   int
+}
+
+// CaseRange is a case value written low..high. It matches numbers from Low to
+// High, inclusive.
+type CaseRange struct {
+	Low  Expr
+	High Expr
+	Line int
+}
+
+func (r *CaseRange) String() string {
+	return fmt.Sprintf("%s..%s", r.Low, r.High)
+}
+
+func (s *SwitchStmt) String() string {
+	val := fmt.Sprintf("switch (%s) {\n", s.Discriminant)
+	for _, c := range s.Cases {
+		values := make([]string, len(c.Values))
+		for i, value := range c.Values {
+			values[i] = value.String()
+		}
+		val += fmt.Sprintf("case %s:\n", strings.Join(values, ", "))
+		for _, statement := range c.Body {
+			val += fmt.Sprintf("%s\n", statement.String())
+		}
+	}
+	if s.HasDefault {
+		val += "default:\n"
+		for _, statement := range s.Default {
+			val += fmt.Sprintf("%s\n", statement.String())
+		}
+	}
+	val += "}"
+	return val
+}
+
+// TryStmt runs Body and, when a value is thrown from it, runs Catch with the
+// value bound to CatchName. Finally always runs last, however the try and
+// catch bodies complete.
+type TryStmt struct {
+	Body       *BlockStmt
+	CatchName  token.Token
+	Catch      *BlockStmt
+	HasCatch   bool
+	Finally    *BlockStmt
+	HasFinally bool
+	Line       int
+}
+
+func (t *TryStmt) String() string {
+	val := fmt.Sprintf("try %s", t.Body)
+	if t.HasCatch {
+		val += fmt.Sprintf(" catch (%s) %s", t.CatchName.Lexeme, t.Catch)
+	}
+	if t.HasFinally {
+		val += fmt.Sprintf(" finally %s", t.Finally)
+	}
+	return val
+}
+
+// ThrowStmt throws Value to the nearest enclosing try.
+type ThrowStmt struct {
+	Keyword token.Token
+	Value   Expr
+	Line    int
+}
+
+func (t *ThrowStmt) String() string {
+	return fmt.Sprintf("throw %s", t.Value)
+}
+
 type BreakStmt struct {
 	Line int
 }
@@ -153,6 +286,7 @@ type FunctionStmt struct {
 	Name   token.Token
 	Params []token.Token
 	Body   []Stmt
+	Line   int
 }
 
 func (f *FunctionStmt) String() string {
@@ -0,0 +1,90 @@
+// Package borno runs Borno programs from Go.
+//
+// The scanner, parser and interpreter still report diagnostics through the
+// shared flags in utils, so Run is not safe for concurrent use.
+package borno
+
+import (
+	"errors"
+	"io"
+	"os"
+
+	"github.com/ah-naf/borno/interpreter"
+	"github.com/ah-naf/borno/lexer"
+	"github.com/ah-naf/borno/parser"
+	"github.com/ah-naf/borno/utils"
+)
+
+// Run scans, parses and interprets source, writing program output to
+// os.Stdout. It returns the value of each top-level statement.
+func Run(source string) ([]interface{}, error) {
+	return RunTo(os.Stdout, source)
+}
+
+// RunTo is like Run but writes program output to out.
+//
+// Scan and parse errors are all returned, joined with errors.Join, and nothing
+// is executed. A runtime error stops execution and is returned as a
+// *utils.RuntimeErrorInfo.
+func RunTo(out io.Writer, source string) ([]interface{}, error) {
+	i := interpreter.NewInterpreter()
+	i.Stdout = out
+	return run(i, source)
+}
+
+// TestReport counts the নিশ্চিত_সমান assertions made by a test file.
+type TestReport struct {
+	Passed int
+	Failed int
+}
+
+// RunTests runs the script at path in test mode, writing program output to
+// out. Failed assertions are reported on stderr and counted rather than
+// stopping the script, so the error is only non-nil when the file cannot be
+// read, does not parse, or stops on another runtime error.
+func RunTests(path string, out io.Writer) (TestReport, error) {
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return TestReport{}, err
+	}
+
+	i := interpreter.NewInterpreter()
+	i.Stdout = out
+	i.TestMode = true
+	_, err = run(i, string(source))
+	return TestReport{Passed: i.AssertionsPassed, Failed: i.AssertionsFailed}, err
+}
+
+func run(i *interpreter.Interpreter, source string) ([]interface{}, error) {
+	utils.HadError = false
+	utils.HadRuntimeError = false
+	utils.LastRuntimeError = nil
+
+	tokens, scanErrors := lexer.NewScanner([]rune(source)).ScanTokens()
+	statements, parseErrors := parser.NewParser(tokens).Parse()
+
+	var errs []error
+	for _, err := range scanErrors {
+		errs = append(errs, err)
+	}
+	errs = append(errs, parseErrors...)
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	results := i.Interpret(statements, false)
+	if utils.HadRuntimeError {
+		return nil, utils.LastRuntimeError
+	}
+	return results, nil
+}
+
+// RunFile reads the script at path and runs it, writing output to os.Stdout.
+func RunFile(path string) error {
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	_, err = Run(string(source))
+	return err
+}
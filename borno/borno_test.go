@@ -0,0 +1,159 @@
+package borno
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ah-naf/borno/utils"
+)
+
+func ExampleRunTo() {
+	var out bytes.Buffer
+	if _, err := RunTo(&out, "দেখাও ৪২;"); err != nil {
+		fmt.Println(err)
+	}
+	fmt.Print(out.String())
+	// Output: 42
+}
+
+func TestRunResults(t *testing.T) {
+	var out bytes.Buffer
+	results, err := RunTo(&out, "ধরি x = 2; x * 21;")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected := []interface{}{nil, 42.0}; !reflect.DeepEqual(results, expected) {
+		t.Fatalf("Expected %v, got %v", expected, results)
+	}
+	if out.Len() != 0 {
+		t.Fatalf("Expected no output, got %q", out.String())
+	}
+}
+
+func TestRunParseErrors(t *testing.T) {
+	var out bytes.Buffer
+	_, err := RunTo(&out, "দেখাও ;\nধরি = 1;")
+	if err == nil {
+		t.Fatal("Expected parse errors")
+	}
+	if lines := strings.Split(err.Error(), "\n"); len(lines) != 2 {
+		t.Fatalf("Expected 2 errors, got %q", err.Error())
+	}
+}
+
+func TestRunRuntimeError(t *testing.T) {
+	var out bytes.Buffer
+	_, err := RunTo(&out, "দেখাও 1;\nদেখাও y;\nদেখাও 2;")
+	runtimeErr, ok := err.(*utils.RuntimeErrorInfo)
+	if !ok {
+		t.Fatalf("Expected a runtime error, got %v", err)
+	}
+	if runtimeErr.Line != 2 || runtimeErr.Message != "Variable y is not defined." {
+		t.Fatalf("Unexpected runtime error: %v", runtimeErr)
+	}
+	if out.String() != "1\n" {
+		t.Fatalf("Expected output to stop at the error, got %q", out.String())
+	}
+}
+
+func TestRunFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "script.bn")
+	if err := os.WriteFile(path, []byte("ধরি x = ;"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := RunFile(path); err == nil {
+		t.Fatal("Expected a parse error")
+	}
+	if err := RunFile(filepath.Join(t.TempDir(), "missing.bn")); err == nil {
+		t.Fatal("Expected an error for a missing file")
+	}
+}
+
+func TestRunTestsExample(t *testing.T) {
+	var out bytes.Buffer
+	report, err := RunTests(filepath.Join("..", "example", "assert.bn"), &out)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if report != (TestReport{Passed: 4, Failed: 0}) {
+		t.Fatalf("Unexpected report: %+v", report)
+	}
+}
+
+func TestRunTestsCountsFailures(t *testing.T) {
+	var out bytes.Buffer
+	report, err := RunTests(filepath.Join("testdata", "failing.bn"), &out)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if report != (TestReport{Passed: 2, Failed: 2}) {
+		t.Fatalf("Unexpected report: %+v", report)
+	}
+	if out.String() != "শেষ\n" {
+		t.Fatalf("Expected the script to run to the end, got %q", out.String())
+	}
+}
+
+func TestExamplesRun(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("..", "example", "*.bn"))
+	if err != nil || len(paths) == 0 {
+		t.Fatalf("Expected example scripts, got %v (%v)", paths, err)
+	}
+
+	quietStdio(t)
+	for _, path := range paths {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			source, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var out bytes.Buffer
+			if _, err := RunTo(&out, string(source)); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestReadmeNativeLibraryDemo(t *testing.T) {
+	readme, err := os.ReadFile(filepath.Join("..", "README.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, demo, found := strings.Cut(string(readme), "### Native Library Demo")
+	if found {
+		_, demo, found = strings.Cut(demo, "```none\n")
+	}
+	if found {
+		demo, _, found = strings.Cut(demo, "```")
+	}
+	if !found {
+		t.Fatal("Expected a Native Library Demo code block in the README")
+	}
+
+	quietStdio(t)
+	var out bytes.Buffer
+	if _, err := RunTo(&out, demo); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+// quietStdio gives scripts that read input an empty stdin instead of waiting
+// on the test's, and discards what they write to stderr, until t finishes.
+func quietStdio(t *testing.T) {
+	stdin, stderr := os.Stdin, os.Stderr
+	null, err := os.OpenFile(os.DevNull, os.O_RDWR, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stdin, os.Stderr = null, null
+	t.Cleanup(func() {
+		os.Stdin, os.Stderr = stdin, stderr
+		null.Close()
+	})
+}
@@ -1,11 +1,6 @@
 package environment
 
-import (
-	"fmt"
-
-	"github.com/ah-naf/borno/token"
-	"github.com/ah-naf/borno/utils"
-)
+import "fmt"
 
 type Environment struct {
 	Values map[string]interface{}
@@ -46,16 +41,67 @@ func (e *Environment) GetInCurrentScope(name string) (interface{}, error) {
 }
 
 
-func (e *Environment) Assign(name token.Token, value interface{}) {
-	if _, exists := e.Values[name.Lexeme]; exists {
-		e.Values[name.Lexeme] = value
-		return
+// Assign updates an existing variable in the nearest scope that defines it.
+// Assigning never declares a variable, so an undefined name is an error.
+func (e *Environment) Assign(name string, value interface{}) error {
+	if _, exists := e.Values[name]; exists {
+		e.Values[name] = value
+		return nil
 	}
 
 	if e.Parent != nil {
-		e.Parent.Assign(name, value)
-		return
+		return e.Parent.Assign(name, value)
+	}
+
+	return fmt.Errorf("undefined variable '%s'", name)
+}
+
+// Copy returns a new environment with the same parent holding the current
+// values of e's own variables.
+func (e *Environment) Copy() *Environment {
+	copied := &Environment{Values: make(map[string]interface{}, len(e.Values)), Parent: e.Parent}
+	for name, value := range e.Values {
+		copied.Values[name] = value
 	}
+	return copied
+}
+
+// Snapshot is a saved copy of the bindings of an environment and every scope
+// enclosing it, which Restore can roll back to.
+type Snapshot struct {
+	env    *Environment
+	values map[string]interface{}
+	parent *Snapshot
+}
 
-	utils.RuntimeError(name, "Undefined variable '"+name.Lexeme+"'.")
+// Snapshot records the current bindings of e and its enclosing scopes. The
+// copy is shallow: a variable that is later reassigned or declared is rolled
+// back by Restore, but an array or object that is changed in place is shared
+// with the snapshot and keeps the change.
+func (e *Environment) Snapshot() *Snapshot {
+	snapshot := &Snapshot{env: e, values: make(map[string]interface{}, len(e.Values))}
+	for name, value := range e.Values {
+		snapshot.values[name] = value
+	}
+	if e.Parent != nil {
+		snapshot.parent = e.Parent.Snapshot()
+	}
+	return snapshot
+}
+
+// Restore puts back the bindings recorded by snapshot, removing variables
+// declared since. Each scope is restored in place, so functions that closed
+// over it see the restored values. A snapshot can be restored more than once.
+func (e *Environment) Restore(snapshot *Snapshot) error {
+	if snapshot == nil || snapshot.env != e {
+		return fmt.Errorf("snapshot was not taken from this environment")
+	}
+	for scope := snapshot; scope != nil; scope = scope.parent {
+		values := make(map[string]interface{}, len(scope.values))
+		for name, value := range scope.values {
+			values[name] = value
+		}
+		scope.env.Values = values
+	}
+	return nil
 }
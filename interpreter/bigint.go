@@ -0,0 +1,203 @@
+package interpreter
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/ah-naf/borno/token"
+	"github.com/ah-naf/borno/utils"
+)
+
+// maxBigExponent caps the exponent of an exact big-integer power so that a
+// typo like 2 ** 1000000000 falls back to float arithmetic instead of hanging.
+const maxBigExponent = 1 << 16
+
+// bigOperand returns value as a big.Int when it holds an integer: a *big.Int,
+// an int64, or a finite float64 without a fractional part.
+func bigOperand(value interface{}) (*big.Int, bool) {
+	switch v := value.(type) {
+	case *big.Int:
+		return v, true
+	case int64:
+		return big.NewInt(v), true
+	case float64:
+		if math.IsInf(v, 0) || math.IsNaN(v) || v != math.Trunc(v) {
+			return nil, false
+		}
+		n, _ := new(big.Float).SetFloat64(v).Int(nil)
+		return n, true
+	}
+	return nil, false
+}
+
+// normalizeBig returns n as a float64 when it is below utils.MaxSafeInteger,
+// so big integers only exist while they are actually needed.
+func normalizeBig(n *big.Int) interface{} {
+	if n.IsInt64() {
+		if v := n.Int64(); v < utils.MaxSafeInteger && v > -utils.MaxSafeInteger {
+			return float64(v)
+		}
+	}
+	return n
+}
+
+// normalizeBigBits is normalizeBig for bitwise results, which are int64 when
+// they fit like the results of the int64 bitwise operators.
+func normalizeBigBits(n *big.Int) interface{} {
+	if n.IsInt64() {
+		return n.Int64()
+	}
+	return n
+}
+
+// bigToFloat approximates n as a float64 for arithmetic that mixes a big
+// integer with a fractional number.
+func bigToFloat(n *big.Int) float64 {
+	f, _ := new(big.Float).SetInt(n).Float64()
+	return f
+}
+
+// beyondFloat reports whether value is an integer that a float64 cannot hold
+// exactly: a *big.Int, or an int64 from the bitwise operators whose magnitude
+// is at least 2^53.
+func beyondFloat(value interface{}) bool {
+	switch v := value.(type) {
+	case *big.Int:
+		return true
+	case int64:
+		return v >= utils.MaxSafeInteger || v <= -utils.MaxSafeInteger
+	}
+	return false
+}
+
+// needsBig reports whether an operation on two plain numbers produces an
+// integer too large for float64 or int64 to hold exactly.
+func needsBig(left interface{}, operator token.TokenType, right interface{}) bool {
+	switch operator {
+	case token.PLUS, token.MINUS, token.STAR, token.POWER:
+		leftNum, err := toNumber(left)
+		if err != nil || !isNumber(left) {
+			return false
+		}
+		rightNum, err := toNumber(right)
+		if err != nil || !isNumber(right) {
+			return false
+		}
+		var result float64
+		switch operator {
+		case token.PLUS:
+			result = leftNum + rightNum
+		case token.MINUS:
+			result = leftNum - rightNum
+		case token.STAR:
+			result = leftNum * rightNum
+		case token.POWER:
+			if rightNum < 0 {
+				return false
+			}
+			result = math.Pow(leftNum, rightNum)
+		}
+		// 2^53 itself is ambiguous, since 2^53 + 1 rounds to it
+		if math.Abs(result) < utils.MaxSafeInteger {
+			return false
+		}
+	case token.LEFT_SHIFT:
+		shift, ok := right.(int64)
+		if !ok {
+			if f, isFloat := right.(float64); isFloat && f == math.Trunc(f) {
+				shift = int64(f)
+			} else {
+				return false
+			}
+		}
+		value, err := toInt64(left)
+		if err != nil || !isNumber(left) || shift < 0 {
+			return false
+		}
+		if shift < 63 && (value<<uint(shift))>>uint(shift) == value {
+			return false
+		}
+	default:
+		return false
+	}
+	_, leftOk := bigOperand(left)
+	_, rightOk := bigOperand(right)
+	return leftOk && rightOk
+}
+
+// evaluateBig performs exact integer arithmetic when either operand is an
+// integer beyond the exact float range or when integer arithmetic on plain
+// numbers would lose precision. It reports ok == false when the operation is
+// not an exact integer one, such as a big integer mixed with a fraction or an
+// uneven division, so the general handlers can fall back to float arithmetic.
+func evaluateBig(left interface{}, operator token.Token, right interface{}) (interface{}, bool) {
+	if !beyondFloat(left) && !beyondFloat(right) && !needsBig(left, operator.Type, right) {
+		return nil, false
+	}
+	l, leftOk := bigOperand(left)
+	r, rightOk := bigOperand(right)
+	if !leftOk || !rightOk {
+		return nil, false
+	}
+
+	switch operator.Type {
+	case token.PLUS:
+		return normalizeBig(new(big.Int).Add(l, r)), true
+	case token.MINUS:
+		return normalizeBig(new(big.Int).Sub(l, r)), true
+	case token.STAR:
+		return normalizeBig(new(big.Int).Mul(l, r)), true
+	case token.SLASH:
+		if r.Sign() == 0 {
+			return nil, false
+		}
+		quotient, remainder := new(big.Int).QuoRem(l, r, new(big.Int))
+		if remainder.Sign() != 0 {
+			return nil, false
+		}
+		return normalizeBig(quotient), true
+	case token.MODULO:
+		if r.Sign() == 0 {
+			return nil, false
+		}
+		// Rem truncates like math.Mod, so the result takes the sign of the dividend
+		return normalizeBig(new(big.Int).Rem(l, r)), true
+	case token.POWER:
+		if r.Sign() < 0 || r.Cmp(big.NewInt(maxBigExponent)) > 0 {
+			return nil, false
+		}
+		return normalizeBig(new(big.Int).Exp(l, r, nil)), true
+	case token.EQUAL_EQUAL:
+		return l.Cmp(r) == 0, true
+	case token.BANG_EQUAL:
+		return l.Cmp(r) != 0, true
+	case token.LESS:
+		return l.Cmp(r) < 0, true
+	case token.LESS_EQUAL:
+		return l.Cmp(r) <= 0, true
+	case token.GREATER:
+		return l.Cmp(r) > 0, true
+	case token.GREATER_EQUAL:
+		return l.Cmp(r) >= 0, true
+	case token.AND:
+		return normalizeBigBits(new(big.Int).And(l, r)), true
+	case token.OR:
+		return normalizeBigBits(new(big.Int).Or(l, r)), true
+	case token.XOR:
+		return normalizeBigBits(new(big.Int).Xor(l, r)), true
+	case token.LEFT_SHIFT, token.RIGHT_SHIFT:
+		if r.Sign() < 0 {
+			utils.RuntimeError(operator, "Shift amount must be non-negative.")
+			return nil, true
+		}
+		if !r.IsInt64() || r.Int64() > maxBigExponent {
+			utils.RuntimeError(operator, "Shift amount is too large.")
+			return nil, true
+		}
+		if operator.Type == token.LEFT_SHIFT {
+			return normalizeBigBits(new(big.Int).Lsh(l, uint(r.Int64()))), true
+		}
+		return normalizeBigBits(new(big.Int).Rsh(l, uint(r.Int64()))), true
+	}
+	return nil, false
+}
@@ -0,0 +1,165 @@
+package interpreter
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+
+	"github.com/ah-naf/borno/token"
+)
+
+// decimalDivisionScale is the number of decimal places a quotient keeps in
+// decimal mode. Quotients that do not terminate within it, such as 1 / 3, are
+// rounded half to even at the last place.
+const decimalDivisionScale = 20
+
+// decimalFromFloat converts a float64 to the exact decimal it was written as.
+// The shortest representation that reads back as the same float is the
+// literal in the source, so 0.1 becomes exactly one tenth.
+func decimalFromFloat(value float64) (*big.Rat, bool) {
+	if math.IsInf(value, 0) || math.IsNaN(value) {
+		return nil, false
+	}
+	return new(big.Rat).SetString(strconv.FormatFloat(value, 'g', -1, 64))
+}
+
+// decimalOperand returns value as an exact decimal when it is a number.
+func decimalOperand(value interface{}) (*big.Rat, bool) {
+	switch v := value.(type) {
+	case *big.Rat:
+		return v, true
+	case *big.Int:
+		return new(big.Rat).SetInt(v), true
+	case int64:
+		return new(big.Rat).SetInt64(v), true
+	case float64:
+		return decimalFromFloat(v)
+	}
+	return nil, false
+}
+
+// normalizeDecimal turns a whole decimal back into an ordinary integer value
+// so it can still index arrays and drive loops.
+func normalizeDecimal(r *big.Rat) interface{} {
+	if r.IsInt() {
+		return normalizeBig(new(big.Int).Set(r.Num()))
+	}
+	return r
+}
+
+// roundDecimal rounds r half to even at the given number of decimal places.
+func roundDecimal(r *big.Rat, places int) *big.Rat {
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(places)), nil)
+	scaled := new(big.Rat).Mul(r, new(big.Rat).SetInt(scale))
+	quotient, remainder := new(big.Int).QuoRem(scaled.Num(), scaled.Denom(), new(big.Int))
+
+	// Compare twice the remainder with the denominator to find which side of
+	// the halfway point the discarded digits fall on
+	twice := new(big.Int).Abs(remainder)
+	twice.Lsh(twice, 1)
+	if cmp := twice.Cmp(scaled.Denom()); cmp > 0 || (cmp == 0 && quotient.Bit(0) == 1) {
+		if scaled.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+	return new(big.Rat).SetFrac(quotient, scale)
+}
+
+// divideDecimal divides exactly when the quotient terminates within
+// decimalDivisionScale places and rounds it there otherwise.
+func divideDecimal(l, r *big.Rat) *big.Rat {
+	return roundDecimal(new(big.Rat).Quo(l, r), decimalDivisionScale)
+}
+
+// formatDecimal prints r with exactly as many decimal places as it has.
+// Decimal values only ever have denominators made of 2s and 5s, so some power
+// of ten is always a multiple of the denominator.
+func formatDecimal(r *big.Rat) string {
+	places := 0
+	scale := big.NewInt(1)
+	remainder := new(big.Int)
+	for remainder.Rem(scale, r.Denom()).Sign() != 0 {
+		places++
+		scale.Mul(scale, big.NewInt(10))
+	}
+	return r.FloatString(places)
+}
+
+// evaluateDecimal performs decimal-mode arithmetic. It applies when either
+// operand is already a decimal, and to every division of two numbers so that
+// integer quotients such as 1 / 3 are decimals too. It reports ok == false
+// for anything else so the general handlers can take over, including division
+// by zero.
+func evaluateDecimal(left interface{}, operator token.Token, right interface{}) (interface{}, bool) {
+	_, leftIsDecimal := left.(*big.Rat)
+	_, rightIsDecimal := right.(*big.Rat)
+	if !leftIsDecimal && !rightIsDecimal && operator.Type != token.SLASH {
+		return nil, false
+	}
+	l, leftOk := decimalOperand(left)
+	r, rightOk := decimalOperand(right)
+	if !leftOk || !rightOk {
+		return nil, false
+	}
+
+	switch operator.Type {
+	case token.PLUS:
+		return normalizeDecimal(new(big.Rat).Add(l, r)), true
+	case token.MINUS:
+		return normalizeDecimal(new(big.Rat).Sub(l, r)), true
+	case token.STAR:
+		return normalizeDecimal(new(big.Rat).Mul(l, r)), true
+	case token.SLASH:
+		if r.Sign() == 0 {
+			return nil, false
+		}
+		return normalizeDecimal(divideDecimal(l, r)), true
+	case token.MODULO:
+		if r.Sign() == 0 {
+			return nil, false
+		}
+		// Truncate the quotient like math.Mod so the result keeps the sign of
+		// the dividend
+		quotient := new(big.Rat).Quo(l, r)
+		whole := new(big.Int).Quo(quotient.Num(), quotient.Denom())
+		product := new(big.Rat).Mul(r, new(big.Rat).SetInt(whole))
+		return normalizeDecimal(new(big.Rat).Sub(l, product)), true
+	case token.POWER:
+		if !r.IsInt() || !r.Num().IsInt64() {
+			return nil, false
+		}
+		exponent := r.Num().Int64()
+		if exponent > maxBigExponent || exponent < -maxBigExponent {
+			return nil, false
+		}
+		negative := exponent < 0
+		if negative {
+			exponent = -exponent
+		}
+		num := new(big.Int).Exp(l.Num(), big.NewInt(exponent), nil)
+		denom := new(big.Int).Exp(l.Denom(), big.NewInt(exponent), nil)
+		result := new(big.Rat).SetFrac(num, denom)
+		if negative {
+			if result.Sign() == 0 {
+				return nil, false
+			}
+			result = divideDecimal(new(big.Rat).SetInt64(1), result)
+		}
+		return normalizeDecimal(result), true
+	case token.EQUAL_EQUAL:
+		return l.Cmp(r) == 0, true
+	case token.BANG_EQUAL:
+		return l.Cmp(r) != 0, true
+	case token.LESS:
+		return l.Cmp(r) < 0, true
+	case token.LESS_EQUAL:
+		return l.Cmp(r) <= 0, true
+	case token.GREATER:
+		return l.Cmp(r) > 0, true
+	case token.GREATER_EQUAL:
+		return l.Cmp(r) >= 0, true
+	}
+	return nil, false
+}
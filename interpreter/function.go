@@ -1,8 +1,13 @@
 package interpreter
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/ah-naf/borno/ast"
 	"github.com/ah-naf/borno/environment"
+	"github.com/ah-naf/borno/token"
+	"github.com/ah-naf/borno/utils"
 )
 
 type Callable interface {
@@ -10,6 +15,91 @@ type Callable interface {
 	Arity() int
 }
 
+// VariadicCallable is implemented by callables whose Arity is -1 but which
+// still accept a bounded number of arguments. A MaxArity of -1 means there is
+// no upper bound.
+type VariadicCallable interface {
+	Callable
+	MinArity() int
+	MaxArity() int
+}
+
+// ThrownError carries a value thrown inside a function out through Call, so
+// the caller can resume unwinding to the nearest try.
+type ThrownError struct {
+	Value interface{}
+	Line  int
+}
+
+func (t *ThrownError) Error() string {
+	return "Uncaught exception: " + describeThrown(t.Value)
+}
+
+// NativeError is a runtime error raised by a native function. The interpreter
+// reports its message as it is, at the line of the call.
+type NativeError struct {
+	Message string
+}
+
+func (e *NativeError) Error() string {
+	return e.Message
+}
+
+// nativeErrorf formats a NativeError.
+func nativeErrorf(format string, args ...interface{}) error {
+	return &NativeError{Message: fmt.Sprintf(format, args...)}
+}
+
+// ScopedCallable is implemented by natives that need the scope they are
+// called from. The interpreter calls CallInScope instead of Call for them.
+type ScopedCallable interface {
+	Callable
+	CallInScope(i *Interpreter, env *environment.Environment, arguments []interface{}) (interface{}, error)
+}
+
+// LazyCallable is implemented by natives that take lazy sequences such as
+// ranges as they are. Other natives get them built out into arrays.
+type LazyCallable interface {
+	Callable
+	TakesLazyArguments()
+}
+
+// arityBounds returns the smallest and largest argument count a callable
+// accepts, with -1 as the largest meaning unbounded.
+func arityBounds(function Callable) (int, int) {
+	if arity := function.Arity(); arity != -1 {
+		return arity, arity
+	}
+	if variadic, ok := function.(VariadicCallable); ok {
+		return variadic.MinArity(), variadic.MaxArity()
+	}
+	return 0, -1
+}
+
+// checkArity returns a message describing why count arguments cannot be passed
+// to function, or "" if the count is acceptable.
+func checkArity(function Callable, count int) string {
+	min, max := arityBounds(function)
+	switch {
+	case min == max && count != min:
+		return fmt.Sprintf("Expected %s but got %d.", pluralArguments(min), count)
+	case count < min:
+		return fmt.Sprintf("Expected at least %s but got %d.", pluralArguments(min), count)
+	case max != -1 && count > max:
+		return fmt.Sprintf("Expected at most %s but got %d.", pluralArguments(max), count)
+	}
+	return ""
+}
+
+// pluralArguments formats a count of arguments, as in "1 argument" or
+// "2 arguments".
+func pluralArguments(count int) string {
+	if count == 1 {
+		return "1 argument"
+	}
+	return fmt.Sprintf("%d arguments", count)
+}
+
 type Function struct {
 	Declaration *ast.FunctionStmt
 	Closure     *environment.Environment
@@ -19,23 +109,139 @@ func NewFunction(declaration *ast.FunctionStmt, closure *environment.Environment
 	return &Function{Declaration: declaration, Closure: closure}
 }
 
+// callFrame records the user function whose body is running and how many try
+// statements were already executing when it was called. Frames link to their
+// caller's frame and the line of the call, which make up a stack trace.
+type callFrame struct {
+	function   *Function
+	tryNesting int
+	caller     *callFrame
+	line       int
+}
+
+// name is how the frame's function appears in a stack trace.
+func (c *callFrame) name() string {
+	if c.function.Declaration.Name.Lexeme == "" {
+		return "<anonymous>"
+	}
+	return c.function.Declaration.Name.Lexeme
+}
+
+// recordTrace keeps the stack of running functions when err or a runtime
+// error is about to unwind past every try, so Interpret can print it under
+// the error. Only the innermost function records it, while the whole stack
+// is still in place.
+func (i *Interpreter) recordTrace(err error) {
+	if i.trace != nil {
+		return
+	}
+	line := 0
+	if thrown, ok := err.(*ThrownError); ok && i.tryNesting == 0 {
+		line = thrown.Line
+	} else if utils.HadRuntimeError && utils.TryDepth == 0 && utils.LastRuntimeError != nil {
+		line = utils.LastRuntimeError.Line
+	} else {
+		return
+	}
+
+	// Each frame is at the line where it called the frame inside it
+	for frame := i.frame; frame != nil; frame = frame.caller {
+		i.trace = append(i.trace, fmt.Sprintf("at %s (line %d)", frame.name(), line))
+		line = frame.line
+	}
+}
+
+// tailCall is returned in place of a value by a return whose expression calls
+// the running function again. Function.Call rebinds the parameters to its
+// arguments and runs the body once more instead of recursing.
+type tailCall struct {
+	arguments []interface{}
+}
+
+// tailCall handles `return f(...)` when f is the function currently running
+// and no try statement inside it is active, so the call is the last thing the
+// function does. It reports ok == false when the call is not such a self-call,
+// leaving the return to evaluate it normally.
+func (i *Interpreter) tailCall(call *ast.Call, env *environment.Environment, isRepl bool) (*ControlFlowSignal, bool) {
+	name, isIdentifier := call.Callee.(*ast.Identifier)
+	if !isIdentifier || i.frame == nil || i.frame.tryNesting != i.tryNesting {
+		return nil, false
+	}
+	callee, signal := i.eval(name, env, isRepl)
+	if signal.Type != ControlFlowNone || utils.HadRuntimeError {
+		return signal, true
+	}
+	if callee != i.frame.function {
+		return nil, false
+	}
+
+	arguments, signal := i.evalElements(call.Arguments, env, isRepl)
+	if signal.Type != ControlFlowNone {
+		return signal, true
+	}
+	if arguments == nil {
+		return &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}, true
+	}
+	if message := checkArity(i.frame.function, len(arguments)); message != "" {
+		utils.RuntimeError(token.Token{Line: call.Line}, message)
+		return &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}, true
+	}
+	return &ControlFlowSignal{Type: ControlFlowReturn, Value: &tailCall{arguments: arguments}}, true
+}
+
 func (f *Function) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	outer := i.frame
+	i.frame = &callFrame{function: f, tryNesting: i.tryNesting, caller: outer, line: i.callLine}
+	defer func() { i.frame = outer }()
+
+	// Tail calls back into f loop here rather than growing the Go stack
+	for {
+		result, err := f.run(i, arguments)
+		tail, ok := result.(*tailCall)
+		if !ok || err != nil {
+			i.recordTrace(err)
+			return result, err
+		}
+		arguments = tail.arguments
+	}
+}
+
+// run executes the body of f once with the given arguments.
+func (f *Function) run(i *Interpreter, arguments []interface{}) (interface{}, error) {
 	functionEnv := environment.NewEnvironmentWithParent(f.Closure)
 
-	functionEnv.Define(f.Declaration.Name.Lexeme, f)
+	// A declared function is already bound in the scope that closes over it,
+	// so its body sees that binding, including any reassignment such as a
+	// memoizing wrapper. The name is only bound here when that scope lacks it,
+	// and an anonymous function has no name to refer to itself by.
+	if name := f.Declaration.Name.Lexeme; name != "" {
+		if _, err := f.Closure.GetInCurrentScope(name); err != nil {
+			functionEnv.Define(name, f)
+		}
+	}
 
 	for ind, param := range f.Declaration.Params {
-		functionEnv.Define(param.Lexeme, arguments[ind])
+		if param.Lexeme != discard {
+			functionEnv.Define(param.Lexeme, arguments[ind])
+		}
 	}
+	hoistFunctions(f.Declaration.Body, functionEnv)
 
 	for _, statment := range f.Declaration.Body {
 		_, signal := i.eval(statment, functionEnv, false)
 		if signal.Type == ControlFlowReturn {
 			return signal.Value, nil
 		}
+		if signal.Type == ControlFlowThrow {
+			return nil, &ThrownError{Value: signal.Value, Line: signal.LineNumber}
+		}
 		if signal.Type != ControlFlowNone {
 			return nil, nil // You can later add support for return values.
 		}
+		// A runtime error ends the call rather than running the statements after it
+		if utils.HadRuntimeError {
+			return nil, nil
+		}
 	}
 	return nil, nil
 }
@@ -46,5 +252,24 @@ func (f *Function) Arity() int {
 }
 
 func (f *Function) String() string {
-	return "<function " + f.Declaration.Name.Lexeme + ">"
+	params := make([]string, len(f.Declaration.Params))
+	for i, param := range f.Declaration.Params {
+		params[i] = param.Lexeme
+	}
+	return "<function " + f.Declaration.Name.Lexeme + "(" + strings.Join(params, ", ") + ")>"
+}
+
+// nativeSignature formats a native's name with the number of arguments it
+// accepts: "<native fn abs/1>", "<native fn split/2-3>" or "<native fn min/1+>"
+// when there is no upper bound.
+func nativeSignature(name string, fn Callable) string {
+	min, max := arityBounds(fn)
+	switch {
+	case max == -1:
+		return fmt.Sprintf("<native fn %s/%d+>", name, min)
+	case min == max:
+		return fmt.Sprintf("<native fn %s/%d>", name, min)
+	default:
+		return fmt.Sprintf("<native fn %s/%d-%d>", name, min, max)
+	}
 }
@@ -1,12 +1,22 @@
 package interpreter
 
 import (
+	"bufio"
 	"fmt"
+	"io"
 	"math"
+	"math/big"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/ah-naf/borno/ast"
 	"github.com/ah-naf/borno/environment"
+	"github.com/ah-naf/borno/lexer"
 	"github.com/ah-naf/borno/token"
 	"github.com/ah-naf/borno/utils"
 	"golang.org/x/text/unicode/norm"
@@ -15,6 +25,82 @@ import (
 // Interpreter struct represents the execution context for evaluating expressions and statements.
 type Interpreter struct {
 	globals *environment.Environment
+
+	// environment is the top-level scope. It is kept across calls to
+	// Interpret so REPL lines see each other's declarations.
+	environment *environment.Environment
+
+	// StrictMode disables implicit string-to-number coercion in arithmetic,
+	// comparison and bitwise operators. Use সংখ্যায়(...) to convert explicitly.
+	StrictMode bool
+
+	// DecimalMode stores numeric literals with a fractional part as exact
+	// decimals, so 0.1 + 0.2 == 0.3. Addition, subtraction and multiplication
+	// of decimals are exact; division rounds half to even at 20 decimal
+	// places.
+	DecimalMode bool
+
+	// Stdout receives the output of দেখাও and REPL echoes. When nil, output
+	// goes to os.Stdout.
+	Stdout io.Writer
+
+	// Stderr receives the output of সতর্ক and failed assertions in test
+	// mode. When nil, it goes to os.Stderr.
+	Stderr io.Writer
+
+	// Precision is the number of significant digits floats are printed with
+	// by দেখাও and REPL echoes. Zero uses the default of 15, which hides
+	// rounding noise such as 0.30000000000000004; a negative value prints
+	// the shortest representation that round-trips exactly.
+	Precision int
+
+	// SuppressEcho stops REPL mode from printing the value of each expression
+	// statement. The values are still returned by Interpret, so a host can
+	// feed lines with REPL semantics and handle the results itself.
+	SuppressEcho bool
+
+	// MaxLoopIterations caps how many times a single loop may run its body, as
+	// a safety net against runaway loops. Zero means no limit.
+	MaxLoopIterations int
+
+	// Stdin is read by ইনপুট and its companions. When nil, input comes
+	// from os.Stdin.
+	Stdin io.Reader
+
+	// stdinReader buffers stdinSource so consecutive reads don't lose data.
+	stdinReader *bufio.Reader
+	stdinSource io.Reader
+
+	// shortCircuited is the optional access, or later link of its chain,
+	// that most recently evaluated to nil because an optional receiver was
+	// nil. The next link reads it through evalReceiver to skip the rest of
+	// the chain.
+	shortCircuited ast.Expr
+
+	// regexCache holds compiled patterns keyed by their source.
+	regexCache map[string]*regexp.Regexp
+
+	// started is when the interpreter was created, the zero point of
+	// মনোটনিক.
+	started time.Time
+
+	// frame is the user function whose body is running, if any, and
+	// tryNesting counts the try statements currently executing. Together they
+	// let a return recognize a tail call it can turn into a loop.
+	frame      *callFrame
+	tryNesting int
+
+	// callLine is the line of the call being made, which the called
+	// function's frame keeps. trace holds the frames of an uncaught error
+	// until Interpret prints them.
+	callLine int
+	trace    []string
+
+	// TestMode makes নিশ্চিত_সমান count and report failed assertions instead
+	// of raising a runtime error, so a test file runs to the end.
+	TestMode         bool
+	AssertionsPassed int
+	AssertionsFailed int
 }
 
 type ControlFlowSignal struct {
@@ -29,12 +115,39 @@ func NewInterpreter() *Interpreter {
 	globals := environment.NewEnvironment()
 
 	globals.Define("ক্লক", NativeClockFn{})
+	globals.Define("মনোটনিক", NativeMonotonicFn{})
+	globals.Define("সময়_মাপো", NativeMeasureFn{})
 	globals.Define("লেন", NativeLenFn{})
+	globals.Define("খালি", NativeEmptyFn{})
+	globals.Define("অ_খালি", NativeNotEmptyFn{})
 	globals.Define("এড", NativeAppendFn{}) // Register `append` function
 	globals.Define("রিমুভ", NativeRemoveFn{})
+	globals.Define("ঢুকাও", NativeInsertFn{})
+	globals.Define("পরিষ্কার", NativeClearFn{})
+	globals.Define("স্লাইস", NativeSliceFn{})
+	globals.Define("সংযুক্ত", NativeConcatFn{})
+	globals.Define("সমতল", NativeFlattenFn{})
+	globals.Define("জিপ", NativeZipFn{})
+	globals.Define("ম্যাপ", NativeMapFn{})
+	globals.Define("ফিল্টার", NativeFilterFn{})
+	globals.Define("প্রত্যেক_উপাদান", NativeForEachFn{})
+	globals.Define("খুঁজে_পাও", NativeFindElementFn{})
+	globals.Define("প্রতিটি", NativeEveryFn{})
+	globals.Define("কিছু", NativeSomeFn{})
 	globals.Define("কি_রিমুভ", NativeDeleteFn{})
+	globals.Define("আছে_কি", NativeHasFn{})
 	globals.Define("অব্জেক্ট_কি", NativeKeysFn{})
 	globals.Define("অব্জেক্ট_মান", NativeValuesFn{})
+	globals.Define("এন্ট্রি", NativeEntriesFn{})
+	globals.Define("এন্ট্রি_থেকে", NativeFromEntriesFn{})
+	globals.Define("কপি", NativeCopyFn{})
+	globals.Define("নেস্টেড_পাও", NativeNestedGetFn{})
+	globals.Define("নেস্টেড_সেট", NativeNestedSetFn{})
+	globals.Define("মান_ম্যাপ", NativeMapValuesFn{})
+	globals.Define("কি_ম্যাপ", NativeMapKeysFn{})
+	globals.Define("আংশিক", NativePartialFn{})
+	globals.Define("নিশ্চিত_সমান", NativeAssertEqualFn{})
+	globals.Define("এরর", NativeErrorFn{})
 
 	globals.Define("পরমমান", NativeAbsFn{})
 	globals.Define("বর্গমূল", NativeSqrtFn{})
@@ -45,12 +158,59 @@ func NewInterpreter() *Interpreter {
 	globals.Define("সর্বনিম্ন", NativeMinFn{})
 	globals.Define("সর্বোচ্চ", NativeMaxFn{})
 	globals.Define("রাউন্ড", NativeRoundFn{})
+	globals.Define("গোল_করে", NativeRoundToFn{})
+	globals.Define("সীমাবদ্ধ", NativeClampFn{})
+	globals.Define("চিহ্ন", NativeSignFn{})
+	globals.Define("হাইপোট", NativeHypotFn{})
+	globals.Define("ভাগশেষ", NativeFloorModFn{})
+	globals.Define("পরিসর", NativeRangeFn{})
+	globals.Define("টেমপ্লেট", NativeTemplateFn{})
+	globals.Define("সাজাও_দ্বারা", NativeSortByFn{})
+	globals.Define("বিপরীত_সাজাও", NativeSortDescendingFn{})
+	globals.Define("একত্র", NativeMergeFn{})
+	globals.Define("নির্বাচন", NativePickFn{})
+	globals.Define("বাদ", NativeOmitFn{})
+	globals.Define("গুণফল", NativeProductFn{})
+	globals.Define("মধ্যমা", NativeMedianFn{})
+	globals.Define("মোড", NativeModeFn{})
+	globals.Define("হেক্স", NativeHexFn{})
+	globals.Define("বাইনারি", NativeBinaryFn{})
+	globals.Define("পার্স_বেস", NativeParseBaseFn{})
+
+	globals.Define("প্রতিস্থাপন", NativeReplaceAllFn{})
+	globals.Define("প্রতিস্থাপন_প্রথম", NativeReplaceFirstFn{})
+	globals.Define("দিয়ে_শুরু", NativeStartsWithFn{})
+	globals.Define("দিয়ে_শেষ", NativeEndsWithFn{})
+	globals.Define("শুরু_ছাঁটো", NativeTrimStartFn{})
+	globals.Define("শেষ_ছাঁটো", NativeTrimEndFn{})
+	globals.Define("বড়হাতে", NativeUpperFn{})
+	globals.Define("ছোটহাতে", NativeLowerFn{})
+	globals.Define("ভাঙো", NativeSplitFn{})
+	globals.Define("রেজেক্স_ভাঙো", NativeRegexSplitFn{})
+	globals.Define("মেলে", NativeMatchFn{})
+	globals.Define("খুঁজো", NativeFindFn{})
+	globals.Define("সব_খুঁজো", NativeFindAllFn{})
 
 	globals.Define("ইনপুট", NativeInputFn{})
+	globals.Define("সংখ্যা_ইনপুট", NativeNumberInputFn{})
+	globals.Define("সব_ইনপুট", NativeReadAllFn{})
+	globals.Define("সতর্ক", NativeWarnFn{})
+	globals.Define("দেখাও_সহ", NativePrintWithFn{})
+	globals.Define("সংখ্যায়", NativeToNumberFn{})
+	globals.Define("ধরন", NativeTypeFn{})
+
+	globals.Define("গ্লোবাল_পাও", NativeGlobalGetFn{})
+	globals.Define("গ্লোবাল_সেট", NativeGlobalSetFn{})
+	globals.Define("সংজ্ঞায়িত", NativeDefinedFn{})
+
+	// Natives are looked up by the normalized names the scanner produces
+	globals.Values = utils.NormalizeNames(globals.Values)
 
 	// Then, create the Interpreter instance with the global environment
 	i := &Interpreter{
-		globals: globals, // Store the reference to the global environment
+		globals:     globals, // Store the reference to the global environment
+		environment: environment.NewEnvironmentWithParent(globals),
+		started:     time.Now(),
 	}
 
 	return i
@@ -61,11 +221,73 @@ const (
 	ControlFlowBreak
 	ControlFlowContinue
 	ControlFlowReturn
+	ControlFlowThrow
 )
 
+// discard is the throwaway name. Declaring, assigning or looping into it
+// evaluates the value and drops it, so it can be bound any number of times
+// and is never defined.
+const discard = "_"
+
+// Snapshot checkpoints the top-level variables so a host can roll back
+// whatever later calls to Interpret change. Like environment's Snapshot, it
+// does not copy arrays or objects.
+func (i *Interpreter) Snapshot() *environment.Snapshot {
+	return i.environment.Snapshot()
+}
+
+// Restore rolls the top-level variables back to a checkpoint taken by
+// Snapshot on this interpreter.
+func (i *Interpreter) Restore(snapshot *environment.Snapshot) error {
+	return i.environment.Restore(snapshot)
+}
+
+// printTrace prints the stack trace recorded for an uncaught error, innermost
+// function first, under the error message.
+func (i *Interpreter) printTrace() {
+	if utils.TryDepth == 0 {
+		for _, frame := range i.trace {
+			fmt.Fprintln(os.Stderr, frame)
+		}
+	}
+	i.trace = nil
+}
+
+func (i *Interpreter) stdout() io.Writer {
+	if i.Stdout != nil {
+		return i.Stdout
+	}
+	return os.Stdout
+}
+
+func (i *Interpreter) stderr() io.Writer {
+	if i.Stderr != nil {
+		return i.Stderr
+	}
+	return os.Stderr
+}
+
+func (i *Interpreter) stdin() *bufio.Reader {
+	var source io.Reader = os.Stdin
+	if i.Stdin != nil {
+		source = i.Stdin
+	}
+	if i.stdinReader == nil || i.stdinSource != source {
+		i.stdinReader = bufio.NewReader(source)
+		i.stdinSource = source
+	}
+	return i.stdinReader
+}
+
+// Interpret runs statements in the top-level scope and returns the value of
+// each one, nil for statements that produce no value. In REPL mode the value
+// of each top-level expression statement is also echoed, unless it is nil or
+// SuppressEcho is set; declarations, loops and expression statements nested
+// inside blocks are never echoed.
 func (i *Interpreter) Interpret(statements []ast.Stmt, isRepl bool) []interface{} {
 	var results []interface{}
-	env := environment.NewEnvironmentWithParent(i.globals)
+	env := i.environment
+	hoistFunctions(statements, env)
 
 	for _, statement := range statements {
 		// fmt.Printf("%#v\n", statement)
@@ -79,11 +301,19 @@ func (i *Interpreter) Interpret(statements []ast.Stmt, isRepl bool) []interface{
 		} else if signal.Type == ControlFlowReturn {
 			utils.RuntimeError(token.Token{Line: signal.LineNumber}, "Unexpected 'return' outside of function.")
 			return nil
+		} else if signal.Type == ControlFlowThrow {
+			utils.RuntimeError(token.Token{Line: signal.LineNumber}, "Uncaught exception: "+describeThrown(signal.Value))
+			i.printTrace()
+			return nil
 		}
 		// fmt.Printf("%#v\n", result)
 		if utils.HadRuntimeError {
+			i.printTrace()
 			return nil // Stop execution if a runtime error occurred during evaluation
 		}
+		if _, isExpression := statement.(*ast.ExpressionStatement); isExpression && isRepl && !i.SuppressEcho && result != nil {
+			fmt.Fprintln(i.stdout(), i.display(result))
+		}
 		results = append(results, result)
 	}
 
@@ -102,7 +332,7 @@ func (i *Interpreter) eval(expr ast.Expr, env *environment.Environment, isRepl b
 		// Ensure the object is a map
 		object, ok := objectValue.(map[string]interface{})
 		if !ok {
-			utils.RuntimeError(token.Token{Line: e.Line}, "Invalid object assignment. Not an object.")
+			utils.RuntimeError(token.Token{Line: e.Line}, "প্রপার্টি সেট করা শুধু অব্জেক্টে কাজ করে, পেয়েছি "+typeName(objectValue))
 			return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
 		}
 
@@ -125,71 +355,82 @@ func (i *Interpreter) eval(expr ast.Expr, env *environment.Environment, isRepl b
 			if signal.Type != ControlFlowNone {
 				return nil, signal
 			}
-			
-			// If 'value' is a []rune, convert it to a string
-			if runes, ok := value.([]rune); ok {
-				properties[key] = string(runes)
-			} else {
-				properties[key] = value
-			}
+			properties[key] = value
 		}
 
 		return properties, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
 
 	case *ast.PropertyAccess:
-		objectValue, signal := i.eval(e.Object, env, isRepl)
+		objectValue, skipped, signal := i.evalReceiver(e.Object, env, isRepl)
 		if signal.Type != ControlFlowNone {
 			return nil, signal
 		}
-
-		object, ok := objectValue.(map[string]interface{})
-		if !ok {
-			utils.RuntimeError(token.Token{Line: e.Line}, "Invalid property access. Not an object.")
+		if utils.HadRuntimeError {
 			return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
 		}
-
-		propertyName := e.Property.Lexeme
-		value, exists := object[propertyName]
-		if !exists {
-			utils.RuntimeError(token.Token{Line: e.Line}, "Property '"+propertyName+"' does not exist on object '"+e.Object.String()+"'.")
+		if skipped || (e.Optional && objectValue == nil) {
+			i.shortCircuited = e
 			return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
 		}
 
-		return value, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
+		return propertyValue(e, objectValue), &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
 
 	case *ast.ArrayLiteral:
-		elements := []interface{}{}
-		for _, element := range e.Elements {
-			value, signal := i.eval(element, env, isRepl)
-			if signal.Type != ControlFlowNone {
-				return nil, signal
-			}
-			elements = append(elements, value)
+		elements, signal := i.evalElements(e.Elements, env, isRepl)
+		if signal.Type != ControlFlowNone {
+			return nil, signal
+		}
+		if elements == nil {
+			return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
 		}
 		return elements, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
 
 	case *ast.ArrayAccess:
-		arrayValue, signal := i.eval(e.Array, env, isRepl)
+		arrayValue, skipped, signal := i.evalReceiver(e.Array, env, isRepl)
 		if signal.Type != ControlFlowNone {
 			return nil, signal
 		}
+		if utils.HadRuntimeError {
+			return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
+		}
+		// The index is not evaluated when an optional access short-circuits
+		if skipped || (e.Optional && arrayValue == nil) {
+			i.shortCircuited = e
+			return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
+		}
+		arrayValue = materialize(arrayValue)
 
 		indexValue, signal := i.eval(e.Index, env, isRepl)
 		if signal.Type != ControlFlowNone {
 			return nil, signal
 		}
 
+		// Objects are indexed by key, mirroring property access
+		if object, ok := arrayValue.(map[string]interface{}); ok {
+			key, err := objectKey(indexValue)
+			if err != nil {
+				utils.RuntimeError(token.Token{Line: e.Line}, err.Error())
+				return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
+			}
+			value, exists := object[key]
+			if !exists {
+				utils.RuntimeError(token.Token{Line: e.Line}, "Property '"+key+"' does not exist on object '"+e.Array.String()+"'.")
+				return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
+			}
+			return value, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
+		}
+
 		// Ensure the array is a slice and the index is a number
 		array, ok := arrayValue.([]interface{})
 
 		if !ok {
-			utils.RuntimeError(token.Token{Line: e.Line}, "Invalid array access. Not an array.")
+			utils.RuntimeError(token.Token{Line: e.Line}, "ইনডেক্স শুধু অ্যারে ও অব্জেক্টে কাজ করে, পেয়েছি "+typeName(arrayValue))
 			return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
 		}
 
 		index, err := toInt64(indexValue)
 		if err != nil {
-			utils.RuntimeError(token.Token{Line: e.Line}, "Array index must be an integer.")
+			utils.RuntimeError(token.Token{Line: e.Line}, "অ্যারে ইনডেক্স পূর্ণসংখ্যা হতে হবে, পেয়েছি "+typeName(indexValue))
 			return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
 		}
 
@@ -216,16 +457,26 @@ func (i *Interpreter) eval(expr ast.Expr, env *environment.Environment, isRepl b
 			return nil, signal
 		}
 
+		if object, ok := arrayValue.(map[string]interface{}); ok {
+			key, err := objectKey(indexValue)
+			if err != nil {
+				utils.RuntimeError(token.Token{Line: e.Line}, err.Error())
+				return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
+			}
+			object[key] = newValue
+			return newValue, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
+		}
+
 		// Ensure the array is a slice and the index is a number
 		array, ok := arrayValue.([]interface{})
 		if !ok {
-			utils.RuntimeError(token.Token{Line: e.Line}, "Invalid array assignment. Not an array.")
+			utils.RuntimeError(token.Token{Line: e.Line}, "ইনডেক্সে মান বসানো শুধু অ্যারে ও অব্জেক্টে কাজ করে, পেয়েছি "+typeName(arrayValue))
 			return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
 		}
 
 		index, err := toInt64(indexValue)
 		if err != nil {
-			utils.RuntimeError(token.Token{Line: e.Line}, "Array index must be an integer.")
+			utils.RuntimeError(token.Token{Line: e.Line}, "অ্যারে ইনডেক্স পূর্ণসংখ্যা হতে হবে, পেয়েছি "+typeName(indexValue))
 			return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
 		}
 
@@ -239,12 +490,26 @@ func (i *Interpreter) eval(expr ast.Expr, env *environment.Environment, isRepl b
 		return newValue, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
 
 	case *ast.FunctionStmt:
-		function := NewFunction(e, environment.NewEnvironmentWithParent(env))
+		// Functions are normally hoisted, so keep that definition and its identity
+		if existing, ok := env.Values[e.Name.Lexeme].(*Function); ok && existing.Declaration == e {
+			return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
+		}
+		// The defining scope itself is the closure, so captured variables are
+		// shared by reference rather than copied
+		function := NewFunction(e, env)
 		// fmt.Printf("%#v %#v\n",e.Name.Lexeme, function)
 		env.Define(e.Name.Lexeme, function)
 		return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
 
+	case *ast.FunctionExpr:
+		return NewFunction(e.Declaration, env), &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
+
 	case *ast.Return:
+		if call, ok := e.Value.(*ast.Call); ok {
+			if signal, ok := i.tailCall(call, env, isRepl); ok {
+				return nil, signal
+			}
+		}
 		var value interface{}
 		if e.Value != nil {
 			v, signal := i.eval(e.Value, env, isRepl)
@@ -258,58 +523,111 @@ func (i *Interpreter) eval(expr ast.Expr, env *environment.Environment, isRepl b
 	case *ast.Call:
 		// Step 1: Evaluate the callee (the thing being called)
 
-		callee, signal := i.eval(e.Callee, env, isRepl)
-
-		if signal.Type != ControlFlowNone {
-			return nil, signal
+		var callee interface{}
+		var signal *ControlFlowSignal
+		if access, ok := e.Callee.(*ast.PropertyAccess); ok {
+			// `value.method(args)` dispatches to a built-in for the receiver's
+			// type unless an object has its own property of that name
+			receiver, skipped, signal := i.evalReceiver(access.Object, env, isRepl)
+			if signal.Type != ControlFlowNone {
+				return nil, signal
+			}
+			if utils.HadRuntimeError {
+				return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
+			}
+			if skipped || (access.Optional && receiver == nil) {
+				i.shortCircuited = e
+				return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
+			}
+			receiver = materialize(receiver)
+			if method, ok := lookupMethod(receiver, access.Property.Lexeme); ok {
+				callee = &PartialFunction{Function: method, Bound: []interface{}{receiver}}
+			} else {
+				callee = propertyValue(access, receiver)
+				if utils.HadRuntimeError {
+					return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
+				}
+			}
+		} else {
+			var skipped bool
+			callee, skipped, signal = i.evalReceiver(e.Callee, env, isRepl)
+			if signal.Type != ControlFlowNone {
+				return nil, signal
+			}
+			if skipped {
+				i.shortCircuited = e
+				return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
+			}
 		}
 
 		// Ensure the callee is a callable function
 		function, ok := callee.(Callable)
 		if !ok {
-			utils.RuntimeError(e.Paren, "Can only call functions.")
+			utils.RuntimeError(token.Token{Line: e.Line}, "শুধু ফাংশন কল করা যায়, পেয়েছি "+typeName(callee))
 			return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
 		}
 
-		if function.Arity() != -1 && len(e.Arguments) != function.Arity() {
-			utils.RuntimeError(e.Paren, fmt.Sprintf("Expected %d arguments but %d.", function.Arity(), len(e.Arguments)))
+		// Step 2: Evaluate each argument and collect them in a list
+		arguments, signal := i.evalElements(e.Arguments, env, isRepl)
+		if signal.Type != ControlFlowNone {
+			return nil, signal
+		}
+		if arguments == nil {
 			return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
 		}
 
-		// Step 2: Evaluate each argument and collect them in a list
-		var arguments []interface{}
-		for _, arg := range e.Arguments {
-			argValue, signal := i.eval(arg, env, isRepl)
-			if signal.Type != ControlFlowNone {
-				return nil, signal
-			}
-			arguments = append(arguments, argValue)
+		// Natives expect arrays, so lazy sequences such as ranges are built
+		// out in full before reaching them
+		_, isUserFunction := function.(*Function)
+		_, isLazy := function.(LazyCallable)
+		if !isUserFunction && !isLazy {
+			arguments = materializeAll(arguments)
+		}
+
+		// Spread arguments are only counted once they are expanded
+		if message := checkArity(function, len(arguments)); message != "" {
+			utils.RuntimeError(token.Token{Line: e.Line}, message)
+			return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
 		}
 
 		// Step 3: Call the function and return its result
-		result, err := function.Call(i, arguments)
+		i.callLine = e.Line
+		var result interface{}
+		var err error
+		if scoped, ok := function.(ScopedCallable); ok {
+			result, err = scoped.CallInScope(i, env, arguments)
+		} else {
+			result, err = function.Call(i, arguments)
+		}
+		if thrown, ok := err.(*ThrownError); ok {
+			// A value thrown inside a user function keeps unwinding from here
+			return nil, &ControlFlowSignal{Type: ControlFlowThrow, LineNumber: thrown.Line, Value: thrown.Value}
+		}
+		if native, ok := err.(*NativeError); ok {
+			utils.RuntimeError(token.Token{Line: e.Line}, native.Message)
+			return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
+		}
 		if err != nil {
-			utils.RuntimeError(e.Paren, "Function call failed: "+err.Error())
+			utils.RuntimeError(token.Token{Line: e.Line}, "Function call failed: "+err.Error())
 			return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
 		}
 
 		return result, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
 
 	case *ast.PrintStatement:
-		value, signal := i.eval(e.Expression, env, isRepl)
-		if signal.Type != ControlFlowNone {
-			return value, signal
-		}
-		if utils.HadRuntimeError {
-			return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0} // Stop execution if a runtime error occurred during evaluation
-		}
+		parts := make([]string, 0, len(e.Expressions))
+		for _, expression := range e.Expressions {
+			value, signal := i.eval(expression, env, isRepl)
+			if signal.Type != ControlFlowNone {
+				return value, signal
+			}
+			if utils.HadRuntimeError {
+				return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0} // Stop execution if a runtime error occurred during evaluation
+			}
 
-		if val, ok := value.([]rune); ok {
-			s := string(val)
-			fmt.Println(norm.NFC.String(s))
-		} else {
-			fmt.Println(norm.NFC.String(stringify(value)))
+			parts = append(parts, i.printable(value))
 		}
+		fmt.Fprintln(i.stdout(), strings.Join(parts, " "))
 
 		return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
 
@@ -319,16 +637,14 @@ func (i *Interpreter) eval(expr ast.Expr, env *environment.Environment, isRepl b
 		if signal.Type != ControlFlowNone {
 			return nil, signal
 		}
-		if isRepl && !utils.HadRuntimeError {
-			if val, ok := value.([]rune); ok {
-				fmt.Println(string(val))
-			} else {
-				fmt.Println(stringify(value))
-			}
-		}
 		return value, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
 
 	case *ast.Literal:
+		if f, ok := e.Value.(float64); ok && i.DecimalMode && f != math.Trunc(f) {
+			if d, ok := decimalFromFloat(f); ok {
+				return d, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
+			}
+		}
 		return e.Value, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
 
 	case *ast.Grouping:
@@ -342,6 +658,10 @@ func (i *Interpreter) eval(expr ast.Expr, env *environment.Environment, isRepl b
 		if utils.HadRuntimeError {
 			return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
 		}
+		if i.StrictMode && e.Operator.Type != token.BANG && isString(right) {
+			utils.RuntimeError(e.Operator, operandError(e.Operator, "", "সংখ্যা", right))
+			return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
+		}
 		return evaluateUnary(e.Operator, right), &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
 
 	case *ast.Binary:
@@ -359,6 +679,21 @@ func (i *Interpreter) eval(expr ast.Expr, env *environment.Environment, isRepl b
 		if utils.HadRuntimeError {
 			return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
 		}
+		if i.StrictMode && coercesStrings(e.Operator.Type) {
+			if isString(left) {
+				utils.RuntimeError(e.Operator, operandError(e.Operator, "বাম", "সংখ্যা", left))
+				return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
+			}
+			if isString(right) {
+				utils.RuntimeError(e.Operator, operandError(e.Operator, "ডান", "সংখ্যা", right))
+				return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
+			}
+		}
+		if i.DecimalMode {
+			if result, ok := evaluateDecimal(left, e.Operator, right); ok {
+				return result, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
+			}
+		}
 		return evaluateBinary(left, e.Operator, right), &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
 
 	case *ast.VarStmt:
@@ -373,13 +708,28 @@ func (i *Interpreter) eval(expr ast.Expr, env *environment.Environment, isRepl b
 			}
 			value = v
 		}
-		_, err := env.GetInCurrentScope(e.Name.Lexeme)
-		if err != nil {
-			env.Define(e.Name.Lexeme, value)
-		} else {
-			utils.RuntimeError(token.Token{Line: e.Line}, "Cannot redeclare variable "+e.Name.Lexeme+".")
+		if e.Pattern == nil {
+			i.declare(e.Name.Lexeme, value, e.Line, env, isRepl)
 			return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
 		}
+
+		// An array pattern binds names by position. Missing elements leave
+		// their names nil, like a declaration without an initializer, and
+		// extra elements are ignored
+		array, ok := materialize(value).([]interface{})
+		if !ok {
+			utils.RuntimeError(token.Token{Line: e.Line}, "অ্যারে প্যাটার্ন শুধু অ্যারে ভাঙতে পারে, পেয়েছি "+typeName(value))
+			return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
+		}
+		for index, name := range e.Pattern {
+			var element interface{}
+			if index < len(array) {
+				element = array[index]
+			}
+			if !i.declare(name.Lexeme, element, e.Line, env, isRepl) {
+				break
+			}
+		}
 		return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
 
 	case *ast.VarListStmt:
@@ -402,7 +752,13 @@ func (i *Interpreter) eval(expr ast.Expr, env *environment.Environment, isRepl b
 		if utils.HadRuntimeError {
 			return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
 		}
-		env.Assign(e.Name, val)
+		if e.Name.Lexeme == discard {
+			return val, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
+		}
+		if err := env.Assign(e.Name.Lexeme, val); err != nil {
+			utils.RuntimeError(e.Name, "Undefined variable '"+e.Name.Lexeme+"'.")
+			return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
+		}
 		return val, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
 
 	case *ast.Identifier:
@@ -415,6 +771,7 @@ func (i *Interpreter) eval(expr ast.Expr, env *environment.Environment, isRepl b
 
 	case *ast.BlockStmt:
 		newEnv := environment.NewEnvironmentWithParent(env)
+		hoistFunctions(e.Block, newEnv)
 		for _, statement := range e.Block {
 			_, signal := i.eval(statement, newEnv, isRepl)
 			if signal.Type != ControlFlowNone {
@@ -462,7 +819,7 @@ func (i *Interpreter) eval(expr ast.Expr, env *environment.Environment, isRepl b
 		return i.eval(e.Right, env, isRepl)
 
 	case *ast.While:
-		for {
+		for iterations := 1; ; iterations++ {
 			condVal, signal := i.eval(e.Condition, env, isRepl)
 			if signal.Type != ControlFlowNone {
 				return nil, signal // Propagate signal upwards
@@ -470,11 +827,20 @@ func (i *Interpreter) eval(expr ast.Expr, env *environment.Environment, isRepl b
 			if !isTruthy(condVal) {
 				break
 			}
+			if i.loopLimitExceeded(iterations, e.Line) {
+				return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
+			}
 
 			_, signal = i.eval(e.Body, env, isRepl)
 			if signal.Type == ControlFlowBreak {
 				break // Exit the loop
 			}
+			if signal.Type == ControlFlowReturn || signal.Type == ControlFlowThrow {
+				return nil, signal // Return and throw must escape the loop
+			}
+			if utils.HadRuntimeError {
+				return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
+			}
 		}
 		return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
 
@@ -488,7 +854,7 @@ func (i *Interpreter) eval(expr ast.Expr, env *environment.Environment, isRepl b
 			}
 		}
 
-		for {
+		for iterations := 1; ; iterations++ {
 			// Check the condition
 			if e.Condition != nil {
 				condVal, signal := i.eval(e.Condition, newEnvironement, isRepl)
@@ -499,6 +865,9 @@ func (i *Interpreter) eval(expr ast.Expr, env *environment.Environment, isRepl b
 					break
 				}
 			}
+			if i.loopLimitExceeded(iterations, e.Line) {
+				return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
+			}
 			// Execute the body
 			_, signal := i.eval(e.Body, newEnvironement, isRepl)
 			if signal.Type == ControlFlowBreak {
@@ -509,17 +878,116 @@ func (i *Interpreter) eval(expr ast.Expr, env *environment.Environment, isRepl b
 			} else if signal.Type != ControlFlowNone {
 				return nil, signal
 			}
+			if utils.HadRuntimeError {
+				return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
+			}
+
+			// Variables declared by the initializer get a fresh binding for
+			// each iteration, so closures created in the body keep the value
+			// they saw rather than the one the loop ends with
+			if len(newEnvironement.Values) > 0 {
+				newEnvironement = newEnvironement.Copy()
+			}
+
+			// Execute the increments, left to right
+			for _, increment := range e.Increments {
+				_, signal := i.eval(increment, newEnvironement, isRepl)
+				if signal.Type != ControlFlowNone {
+					return nil, signal
+				}
+				if utils.HadRuntimeError {
+					return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
+				}
+			}
+		}
+		return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
+
+	case *ast.ForEachStmt:
+		iterable, signal := i.eval(e.Iterable, env, isRepl)
+		if signal.Type != ControlFlowNone {
+			return nil, signal
+		}
+		if utils.HadRuntimeError {
+			return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
+		}
+
+		// Ranges are walked lazily, one value at a time
+		iterator, ok := iterate(iterable)
+		if !ok {
+			utils.RuntimeError(token.Token{Line: e.Line}, "লুপ শুধু অ্যারে, অব্জেক্ট ও স্ট্রিংয়ের উপর চলে, পেয়েছি "+typeName(iterable))
+			return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
+		}
+
+		for index := 0; ; index++ {
+			item, ok := iterator.Next()
+			if !ok {
+				break
+			}
+			if i.loopLimitExceeded(index+1, e.Line) {
+				return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
+			}
+			// Each iteration gets a fresh binding so closures capture their own value
+			loopEnv := environment.NewEnvironmentWithParent(env)
+			if e.Variable.Lexeme != discard {
+				loopEnv.Define(e.Variable.Lexeme, item)
+			}
+
+			_, signal := i.eval(e.Body, loopEnv, isRepl)
+			if signal.Type == ControlFlowBreak {
+				break
+			}
+			if signal.Type != ControlFlowNone && signal.Type != ControlFlowContinue {
+				return nil, signal
+			}
+			if utils.HadRuntimeError {
+				return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
+			}
+		}
+		return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
+
+	case *ast.SwitchStmt:
+		value, signal := i.eval(e.Discriminant, env, isRepl)
+		if signal.Type != ControlFlowNone {
+			return nil, signal
+		}
+		if utils.HadRuntimeError {
+			return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
+		}
 
-			// Execute the increment
-			if e.Increment != nil {
-				_, signal := i.eval(e.Increment, newEnvironement, isRepl)
+		for _, c := range e.Cases {
+			for _, caseExpr := range c.Values {
+				matched, signal := i.matchesCase(value, caseExpr, env, isRepl)
 				if signal.Type != ControlFlowNone {
 					return nil, signal
 				}
+				if utils.HadRuntimeError {
+					return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
+				}
+				if matched {
+					return i.executeCase(c.Body, env, isRepl)
+				}
 			}
 		}
+		// The parser keeps the default apart from the cases, so it runs only
+		// after every case failed to match, wherever it appears in the source
+		if e.HasDefault {
+			return i.executeCase(e.Default, env, isRepl)
+		}
 		return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
 
+	case *ast.TryStmt:
+		return i.executeTry(e, env, isRepl)
+
+	case *ast.ThrowStmt:
+		value, signal := i.eval(e.Value, env, isRepl)
+		if signal.Type != ControlFlowNone {
+			return nil, signal
+		}
+		if utils.HadRuntimeError {
+			return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
+		}
+		return nil, &ControlFlowSignal{Type: ControlFlowThrow, LineNumber: e.Keyword.Line, Value: value}
+
 	case *ast.BreakStmt:
 		return nil, &ControlFlowSignal{Type: ControlFlowBreak, LineNumber: e.Line}
 
@@ -528,16 +996,263 @@ func (i *Interpreter) eval(expr ast.Expr, env *environment.Environment, isRepl b
 
 	default:
 		lineNumber := getLineNumber(expr)
-		utils.RuntimeError(token.Token{Line: lineNumber}, "Unknown expression type.")
+		// Reaching here means an AST node was added without a case above,
+		// which is a bug in the interpreter rather than in the program
+		utils.RuntimeError(token.Token{Line: lineNumber}, fmt.Sprintf("Internal error: the interpreter has no case for %T. This is a bug in Borno, not in your program.", expr))
 		return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
 	}
 }
 
+// propertyValue looks up a property on an evaluated object, reporting a
+// runtime error and returning nil if it is missing or the value is not an object.
+func propertyValue(e *ast.PropertyAccess, objectValue interface{}) interface{} {
+	object, ok := objectValue.(map[string]interface{})
+	if !ok {
+		utils.RuntimeError(token.Token{Line: e.Line}, "প্রপার্টি পড়া শুধু অব্জেক্টে কাজ করে, পেয়েছি "+typeName(objectValue))
+		return nil
+	}
+
+	propertyName := e.Property.Lexeme
+	value, exists := object[propertyName]
+	if !exists {
+		utils.RuntimeError(token.Token{Line: e.Line}, "Property '"+propertyName+"' does not exist on object '"+e.Object.String()+"'.")
+		return nil
+	}
+	return value
+}
+
+// evalReceiver evaluates the object of a property access or index, or the
+// callee of a call. skipped reports that expr is part of an optional chain
+// that short-circuited, so a?.b.c is nil when a is nil instead of failing on
+// the .c.
+func (i *Interpreter) evalReceiver(expr ast.Expr, env *environment.Environment, isRepl bool) (interface{}, bool, *ControlFlowSignal) {
+	value, signal := i.eval(expr, env, isRepl)
+	skipped := i.shortCircuited != nil && i.shortCircuited == expr
+	i.shortCircuited = nil
+	return value, skipped, signal
+}
+
+// evalElements evaluates call arguments or array literal elements, expanding
+// any spread operands in place. It returns nil after a runtime error.
+func (i *Interpreter) evalElements(exprs []ast.Expr, env *environment.Environment, isRepl bool) ([]interface{}, *ControlFlowSignal) {
+	values := []interface{}{}
+	for _, expr := range exprs {
+		spread, isSpread := expr.(*ast.Spread)
+		if isSpread {
+			expr = spread.Expression
+		}
+
+		value, signal := i.eval(expr, env, isRepl)
+		if signal.Type != ControlFlowNone {
+			return nil, signal
+		}
+		if utils.HadRuntimeError {
+			return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
+		}
+
+		if !isSpread {
+			values = append(values, value)
+			continue
+		}
+		array, ok := materialize(value).([]interface{})
+		if !ok {
+			utils.RuntimeError(token.Token{Line: spread.Line}, "স্প্রেড শুধু অ্যারেতে কাজ করে, পেয়েছি "+typeName(value))
+			return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
+		}
+		values = append(values, array...)
+	}
+	return values, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
+}
+
+// iterationItems returns the values a foreach loop visits: the elements of an
+// array, the keys of an object in sorted order, or the characters of a string
+// as one-rune strings.
+func iterationItems(value interface{}) ([]interface{}, bool) {
+	switch v := value.(type) {
+	case []interface{}:
+		return v, true
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		items := make([]interface{}, len(keys))
+		for index, key := range keys {
+			items[index] = key
+		}
+		return items, true
+	case string:
+		runes := []rune(v)
+		items := make([]interface{}, len(runes))
+		for index, r := range runes {
+			items[index] = string(r)
+		}
+		return items, true
+	}
+	return nil, false
+}
+
+// declare binds a variable declared with ধরি in env, reporting a runtime
+// error and returning false if the scope already has it. Re-running a
+// top-level declaration in the REPL rebinds the variable instead, and the
+// discard name is never bound at all.
+func (i *Interpreter) declare(name string, value interface{}, line int, env *environment.Environment, isRepl bool) bool {
+	if name == discard {
+		return true
+	}
+	if _, err := env.GetInCurrentScope(name); err == nil && !(isRepl && env == i.environment) {
+		utils.RuntimeError(token.Token{Line: line}, "Cannot redeclare variable "+name+".")
+		return false
+	}
+	env.Define(name, value)
+	return true
+}
+
+// hoistFunctions defines every function declared directly in statements
+// before any of them run, so a function can be called before its declaration
+// and functions in the same block can call each other.
+func hoistFunctions(statements []ast.Stmt, env *environment.Environment) {
+	for _, statement := range statements {
+		if declaration, ok := statement.(*ast.FunctionStmt); ok {
+			env.Define(declaration.Name.Lexeme, NewFunction(declaration, env))
+		}
+	}
+}
+
+// loopLimitExceeded reports a runtime error and returns true when a loop is
+// about to start an iteration beyond MaxLoopIterations.
+func (i *Interpreter) loopLimitExceeded(iterations int, line int) bool {
+	if i.MaxLoopIterations <= 0 || iterations <= i.MaxLoopIterations {
+		return false
+	}
+	utils.RuntimeError(token.Token{Line: line}, "Loop iteration limit exceeded.")
+	return true
+}
+
+// matchesCase reports whether a switch discriminant matches one case value.
+// Arrays and objects match by content, not identity, and a range matches
+// numbers between its bounds, inclusive.
+func (i *Interpreter) matchesCase(value interface{}, caseExpr ast.Expr, env *environment.Environment, isRepl bool) (bool, *ControlFlowSignal) {
+	caseRange, isRange := caseExpr.(*ast.CaseRange)
+	if !isRange {
+		caseValue, signal := i.eval(caseExpr, env, isRepl)
+		if signal.Type != ControlFlowNone || utils.HadRuntimeError {
+			return false, signal
+		}
+		return deepEqual(value, caseValue, make(map[[2]uintptr]bool)), signal
+	}
+
+	low, signal := i.eval(caseRange.Low, env, isRepl)
+	if signal.Type != ControlFlowNone || utils.HadRuntimeError {
+		return false, signal
+	}
+	high, signal := i.eval(caseRange.High, env, isRepl)
+	if signal.Type != ControlFlowNone || utils.HadRuntimeError {
+		return false, signal
+	}
+	if !isNumber(low) || !isNumber(high) {
+		bound := low
+		if isNumber(low) {
+			bound = high
+		}
+		utils.RuntimeError(token.Token{Line: caseRange.Line}, "কেস পরিসরের সীমা সংখ্যা হতে হবে, পেয়েছি "+typeName(bound))
+		return false, signal
+	}
+	if !isNumber(value) {
+		return false, signal
+	}
+	number, _ := toNumber(value)
+	lowNumber, _ := toNumber(low)
+	highNumber, _ := toNumber(high)
+	return number >= lowNumber && number <= highNumber, signal
+}
+
+// executeCase runs a switch case body in its own scope. A break binds to the
+// nearest enclosing loop or switch, so the switch consumes it here; continue
+// and return propagate to the enclosing loop or function.
+func (i *Interpreter) executeCase(body []ast.Stmt, env *environment.Environment, isRepl bool) (interface{}, *ControlFlowSignal) {
+	caseEnv := environment.NewEnvironmentWithParent(env)
+	hoistFunctions(body, caseEnv)
+	for _, statement := range body {
+		_, signal := i.eval(statement, caseEnv, isRepl)
+		if signal.Type == ControlFlowBreak {
+			break
+		}
+		if signal.Type != ControlFlowNone {
+			return nil, signal
+		}
+		if utils.HadRuntimeError {
+			break
+		}
+	}
+	return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
+}
+
+// executeTry runs a try statement. A throw from the body is handed to the
+// catch clause, and the finally block runs after the body and catch however
+// they completed. If finally itself completes abruptly (throw, return, break
+// or continue), that signal replaces the pending one.
+func (i *Interpreter) executeTry(e *ast.TryStmt, env *environment.Environment, isRepl bool) (interface{}, *ControlFlowSignal) {
+	i.tryNesting++
+	defer func() { i.tryNesting-- }()
+
+	utils.TryDepth++
+	_, signal := i.eval(e.Body, env, isRepl)
+	utils.TryDepth--
+	if utils.HadRuntimeError {
+		// Runtime errors inside a try are thrown as error objects
+		utils.HadRuntimeError = false
+		runtimeError := utils.LastRuntimeError
+		signal = &ControlFlowSignal{
+			Type:       ControlFlowThrow,
+			LineNumber: runtimeError.Line,
+			Value:      newErrorObject("RuntimeError", runtimeError.Message),
+		}
+	}
+
+	if signal.Type == ControlFlowThrow && e.HasCatch {
+		catchEnv := environment.NewEnvironmentWithParent(env)
+		catchEnv.Define(e.CatchName.Lexeme, signal.Value)
+		_, signal = i.eval(e.Catch, catchEnv, isRepl)
+		if utils.HadRuntimeError {
+			// The finally block still runs before a runtime error in the
+			// catch body propagates, unless it fails or jumps itself
+			if e.HasFinally {
+				runtimeError := utils.LastRuntimeError
+				utils.HadRuntimeError = false
+				_, finallySignal := i.eval(e.Finally, env, isRepl)
+				if finallySignal.Type != ControlFlowNone || utils.HadRuntimeError {
+					return nil, finallySignal
+				}
+				utils.HadRuntimeError = true
+				utils.LastRuntimeError = runtimeError
+			}
+			return nil, &ControlFlowSignal{Type: ControlFlowNone, LineNumber: 0}
+		}
+	}
+
+	if e.HasFinally {
+		_, finallySignal := i.eval(e.Finally, env, isRepl)
+		if finallySignal.Type != ControlFlowNone || utils.HadRuntimeError {
+			return nil, finallySignal
+		}
+	}
+	return nil, signal
+}
+
 func evaluateBinary(left interface{}, operator token.Token, right interface{}) interface{} {
 	if utils.HadRuntimeError {
 		return nil
 	}
 
+	if result, ok := evaluateNumeric(left, operator.Type, right); ok {
+		return result
+	}
+	if result, ok := evaluateBig(left, operator, right); ok {
+		return result
+	}
+
 	switch operator.Type {
 	case token.PLUS:
 		return handleAddition(left, right, operator)
@@ -557,12 +1272,12 @@ func evaluateBinary(left interface{}, operator token.Token, right interface{}) i
 	case token.POWER:
 		leftFloat, err := toNumber(left)
 		if err != nil {
-			utils.RuntimeError(operator, "Left operand must be a number.")
+			utils.RuntimeError(operator, operandError(operator, "বাম", "সংখ্যা", left))
 			return nil
 		}
 		rightFloat, err := toNumber(right)
 		if err != nil {
-			utils.RuntimeError(operator, "Right operand must be a number.")
+			utils.RuntimeError(operator, operandError(operator, "ডান", "সংখ্যা", right))
 			return nil
 		}
 		return math.Pow(leftFloat, rightFloat)
@@ -570,12 +1285,12 @@ func evaluateBinary(left interface{}, operator token.Token, right interface{}) i
 	case token.MODULO:
 		leftNum, err := toNumber(left)
 		if err != nil {
-			utils.RuntimeError(operator, "Left operand must be a number.")
+			utils.RuntimeError(operator, operandError(operator, "বাম", "সংখ্যা", left))
 			return nil
 		}
 		rightNum, err := toNumber(right)
 		if err != nil {
-			utils.RuntimeError(operator, "Right operand must be a number.")
+			utils.RuntimeError(operator, operandError(operator, "ডান", "সংখ্যা", right))
 			return nil
 		}
 		if rightNum == 0 {
@@ -590,6 +1305,77 @@ func evaluateBinary(left interface{}, operator token.Token, right interface{}) i
 	}
 }
 
+// evaluateNumeric is a fast path for arithmetic, comparison and equality on
+// two operands that are already numbers, skipping the toNumber conversions of
+// the general handlers. It produces the same results as those handlers and
+// reports ok == false for anything else, including division by zero, so the
+// general path can raise the error, and for int64 operands too large to
+// convert to a float64 exactly. Sums, differences and products beyond the
+// exact float range also report ok == false so they can be promoted to big
+// integers.
+func evaluateNumeric(left interface{}, operator token.TokenType, right interface{}) (interface{}, bool) {
+	var leftNum, rightNum float64
+	switch l := left.(type) {
+	case float64:
+		leftNum = l
+	case int64:
+		if beyondFloat(l) {
+			return nil, false
+		}
+		leftNum = float64(l)
+	default:
+		return nil, false
+	}
+	switch r := right.(type) {
+	case float64:
+		rightNum = r
+	case int64:
+		if beyondFloat(r) {
+			return nil, false
+		}
+		rightNum = float64(r)
+	default:
+		return nil, false
+	}
+
+	switch operator {
+	case token.PLUS:
+		return exactNumber(leftNum + rightNum)
+	case token.MINUS:
+		return exactNumber(leftNum - rightNum)
+	case token.STAR:
+		return exactNumber(leftNum * rightNum)
+	case token.SLASH:
+		if rightNum == 0 {
+			return nil, false
+		}
+		return leftNum / rightNum, true
+	case token.LESS:
+		return leftNum < rightNum, true
+	case token.LESS_EQUAL:
+		return leftNum <= rightNum, true
+	case token.GREATER:
+		return leftNum > rightNum, true
+	case token.GREATER_EQUAL:
+		return leftNum >= rightNum, true
+	case token.EQUAL_EQUAL:
+		return leftNum == rightNum, true
+	case token.BANG_EQUAL:
+		return leftNum != rightNum, true
+	}
+	return nil, false
+}
+
+// exactNumber passes result through the fast path unless it is too large to be
+// sure the float64 holds it exactly. A result of exactly 2^53 may itself be a
+// rounded 2^53 + 1, so it takes the exact path too.
+func exactNumber(result float64) (interface{}, bool) {
+	if result >= utils.MaxSafeInteger || result <= -utils.MaxSafeInteger {
+		return nil, false
+	}
+	return result, true
+}
+
 func evaluateUnary(operator token.Token, right interface{}) interface{} {
 	if utils.HadRuntimeError {
 		return nil
@@ -597,26 +1383,49 @@ func evaluateUnary(operator token.Token, right interface{}) interface{} {
 	// fmt.Printf("%#v\n", operator)
 	switch operator.Type {
 	case token.MINUS:
+		if n, ok := right.(*big.Int); ok {
+			return new(big.Int).Neg(n)
+		}
+		if d, ok := right.(*big.Rat); ok {
+			return new(big.Rat).Neg(d)
+		}
 		value, err := toNumber(right)
 		if err != nil {
-			utils.RuntimeError(operator, err.Error())
+			utils.RuntimeError(operator, operandError(operator, "", "সংখ্যা", right))
 			return nil
 		}
 		return -value
 
+	case token.PLUS:
+		// Unary plus leaves a number unchanged but still rejects non-numbers
+		switch right.(type) {
+		case *big.Int, *big.Rat:
+			return right
+		}
+		value, err := toNumber(right)
+		if err != nil {
+			utils.RuntimeError(operator, operandError(operator, "", "সংখ্যা", right))
+			return nil
+		}
+		return value
+
 	case token.BANG:
 		return !isTruthy(right)
 
 	case token.NOT:
+		if err := requireIntegral(right); err != nil {
+			utils.RuntimeError(operator, err.Error())
+			return nil
+		}
 		value, err := toInt64(right)
 		if err != nil {
-			utils.RuntimeError(operator, err.Error())
+			utils.RuntimeError(operator, operandError(operator, "", "পূর্ণসংখ্যা", right))
 			return nil
 		}
 		return ^value
 
 	default:
-		utils.RuntimeError(operator, "Unknown unary operator: "+operator.Lexeme)
+		utils.RuntimeError(operator, fmt.Sprintf("Unknown unary operator '%s'. Expected one of '!', '-', '+' or '~'.", operator.Lexeme))
 		return nil
 	}
 }
@@ -624,72 +1433,60 @@ func evaluateUnary(operator token.Token, right interface{}) interface{} {
 // Helper functions to reduce code duplication
 
 func handleAddition(left, right interface{}, operator token.Token) interface{} {
-	// Handle number addition and string concatenation
+	// Handle number addition and string and array concatenation
 	switch l := left.(type) {
-	case int64, float64:
+	case int64, float64, *big.Int, *big.Rat:
 		leftNum, err := toNumber(left)
 		if err != nil {
-			utils.RuntimeError(operator, "Left operand must be a number.")
+			utils.RuntimeError(operator, operandError(operator, "বাম", "সংখ্যা", left))
 			return nil
 		}
 		rightNum, err := toNumber(right)
 		if err == nil {
 			return leftNum + rightNum
 		}
-		rightStr, ok := right.(string)
-		if ok {
-			return fmt.Sprintf("%v", leftNum) + rightStr
-		}
-		if rightStr, ok := right.([]rune); ok {
-			return fmt.Sprintf("%v", leftNum) + string(rightStr)
+		leftStr, _ := stringifyOperand(l)
+		if rightStr, ok := right.(string); ok {
+			return leftStr + rightStr
 		}
 	case string:
 		rightStr, err := stringifyOperand(right)
 		if err != nil {
-			utils.RuntimeError(operator, "Right operand must be a string or number.")
+			utils.RuntimeError(operator, operandError(operator, "ডান", "স্ট্রিং বা সংখ্যা", right))
 			return nil
 		}
 		return l + rightStr
-	case []rune:
-		var rightStr string
-		// Check the type of the right operand:
-		switch r := right.(type) {
-		case []rune:
-			// Both operands are []rune; convert them to strings.
-			rightStr = string(r)
-		case string:
-			rightStr = r
-		case bool:
-			rightStr = "false"
-			if r {
-				rightStr = "true"
-			}
-		default:
-			// If the right operand isn’t directly a string or []rune,
-			// attempt to stringify it using your helper.
-			var err error
-			rightStr, err = stringifyOperand(right)
-			if err != nil {
-				utils.RuntimeError(operator, "Right operand must be a string or number.")
-				return nil
-			}
+	case []interface{}:
+		// Arrays only concatenate with arrays. Appending a single value is
+		// spelled [...a, x] so that a + x never silently nests an array
+		r, ok := right.([]interface{})
+		if !ok {
+			utils.RuntimeError(operator, operandError(operator, "ডান", "অ্যারে", right))
+			return nil
+		}
+		result := make([]interface{}, 0, len(l)+len(r))
+		return append(append(result, l...), r...)
+	case bool, nil:
+		// Booleans and nil only combine with strings, by concatenation
+		if isString(right) {
+			leftStr, _ := stringifyOperand(l)
+			rightStr, _ := stringifyOperand(right)
+			return leftStr + rightStr
 		}
-		// Convert leftVal (a []rune) to a string and add.
-		return string(l) + rightStr
 	}
-	utils.RuntimeError(operator, "Operands must be numbers or strings.")
+	utils.RuntimeError(operator, fmt.Sprintf("'+' অপারেটর %s ও %s যোগ করতে পারে না", typeName(left), typeName(right)))
 	return nil
 }
 
 func handleArithmetic(left, right interface{}, operator token.Token) interface{} {
 	leftNum, err := toNumber(left)
 	if err != nil {
-		utils.RuntimeError(operator, "Left operand must be a number.")
+		utils.RuntimeError(operator, operandError(operator, "বাম", "সংখ্যা", left))
 		return nil
 	}
 	rightNum, err := toNumber(right)
 	if err != nil {
-		utils.RuntimeError(operator, "Right operand must be a number.")
+		utils.RuntimeError(operator, operandError(operator, "ডান", "সংখ্যা", right))
 		return nil
 	}
 
@@ -719,12 +1516,12 @@ func handleEquality(left, right interface{}, operator token.Token) interface{} {
 func handleComparison(left, right interface{}, operator token.Token) interface{} {
 	leftNum, err := toNumber(left)
 	if err != nil {
-		utils.RuntimeError(operator, "Left operand must be a number.")
+		utils.RuntimeError(operator, operandError(operator, "বাম", "সংখ্যা", left))
 		return nil
 	}
 	rightNum, err := toNumber(right)
 	if err != nil {
-		utils.RuntimeError(operator, "Right operand must be a number.")
+		utils.RuntimeError(operator, operandError(operator, "ডান", "সংখ্যা", right))
 		return nil
 	}
 
@@ -742,14 +1539,21 @@ func handleComparison(left, right interface{}, operator token.Token) interface{}
 }
 
 func handleBitwise(left, right interface{}, operator token.Token) interface{} {
+	for _, operand := range []interface{}{left, right} {
+		if err := requireIntegral(operand); err != nil {
+			utils.RuntimeError(operator, err.Error())
+			return nil
+		}
+	}
+
 	leftInt, err := toInt64(left)
 	if err != nil {
-		utils.RuntimeError(operator, "Left operand must be an integer.")
+		utils.RuntimeError(operator, operandError(operator, "বাম", "পূর্ণসংখ্যা", left))
 		return nil
 	}
 	rightInt, err := toInt64(right)
 	if err != nil {
-		utils.RuntimeError(operator, "Right operand must be an integer.")
+		utils.RuntimeError(operator, operandError(operator, "ডান", "পূর্ণসংখ্যা", right))
 		return nil
 	}
 
@@ -760,27 +1564,83 @@ func handleBitwise(left, right interface{}, operator token.Token) interface{} {
 		return leftInt | rightInt
 	case token.XOR:
 		return leftInt ^ rightInt
-	case token.LEFT_SHIFT:
-		return leftInt << rightInt
-	case token.RIGHT_SHIFT:
+	case token.LEFT_SHIFT, token.RIGHT_SHIFT:
+		// Go panics on a negative shift count, so reject it up front
+		if rightInt < 0 {
+			utils.RuntimeError(operator, "Shift amount must be non-negative.")
+			return nil
+		}
+		if operator.Type == token.LEFT_SHIFT {
+			return leftInt << rightInt
+		}
 		return leftInt >> rightInt
-	case token.POWER:
-		return int64(math.Pow(float64(leftInt), float64(rightInt)))
+	}
+	return nil
+}
+
+// requireIntegral rejects floats with a fractional part for bitwise operators
+func requireIntegral(value interface{}) error {
+	if f, ok := value.(float64); ok && f != math.Trunc(f) {
+		return fmt.Errorf("বিটওয়াইজ অপারেটর শুধু পূর্ণসংখ্যায় কাজ করে, পেয়েছি %v", f)
 	}
 	return nil
 }
 
 // Helper functions for type conversions
 
+// coercesStrings reports whether the operator converts numeric strings to
+// numbers in lenient mode. String concatenation with '+' is not coercion.
+func coercesStrings(operator token.TokenType) bool {
+	switch operator {
+	case token.MINUS, token.STAR, token.SLASH, token.MODULO, token.POWER,
+		token.GREATER, token.GREATER_EQUAL, token.LESS, token.LESS_EQUAL,
+		token.AND, token.OR, token.XOR, token.LEFT_SHIFT, token.RIGHT_SHIFT:
+		return true
+	}
+	return false
+}
+
+func isString(value interface{}) bool {
+	switch value.(type) {
+	case string:
+		return true
+	}
+	return false
+}
+
+// signReplacer maps the minus and plus signs people type or paste, such as
+// the Unicode minus and the en dash, to their ASCII forms.
+var signReplacer = strings.NewReplacer(
+	"\u2212", "-", // minus sign
+	"\u2013", "-", // en dash
+	"\u2010", "-", // hyphen
+	"\u2012", "-", // figure dash
+	"\uFE63", "-", // small hyphen-minus
+	"\uFF0D", "-", // fullwidth hyphen-minus
+	"\uFF0B", "+", // fullwidth plus sign
+)
+
+// parseNumericString parses a number written in a string the way a user
+// would type it: with Bangla or ASCII digits, any common minus sign, and
+// surrounding whitespace.
+func parseNumericString(s string) (float64, error) {
+	ascii := utils.ConvertBanglaDigitsToASCII(signReplacer.Replace(strings.TrimSpace(s)))
+	return strconv.ParseFloat(ascii, 64)
+}
+
 func toNumber(value interface{}) (float64, error) {
 	switch v := value.(type) {
 	case int64:
 		return float64(v), nil
+	case *big.Int:
+		return bigToFloat(v), nil
+	case *big.Rat:
+		f, _ := v.Float64()
+		return f, nil
 	case float64:
 		return v, nil
 	case string:
-		ascii := utils.ConvertBanglaDigitsToASCII(v)
-		num, err := strconv.ParseFloat(ascii, 64)
+		num, err := parseNumericString(v)
 		if err != nil {
 			return 0, fmt.Errorf("expected a number, got string %q", v)
 		}
@@ -794,14 +1654,18 @@ func toInt64(value interface{}) (int64, error) {
 	switch v := value.(type) {
 	case int64:
 		return v, nil
+	case *big.Int:
+		if v.IsInt64() {
+			return v.Int64(), nil
+		}
+		return 0, fmt.Errorf("integer %v does not fit in 64 bits", v)
 	case float64:
 		if float64(int64(v)) == v {
 			return int64(v), nil
 		}
 		return 0, fmt.Errorf("expected an integer, got float %v", v)
 	case string:
-		ascii := utils.ConvertBanglaDigitsToASCII(v)
-		num, err := strconv.ParseFloat(ascii, 64)
+		num, err := parseNumericString(v)
 		if err != nil {
 			return 0, fmt.Errorf("expected an integer, got string %q", v)
 		}
@@ -814,12 +1678,31 @@ func toInt64(value interface{}) (int64, error) {
 	}
 }
 
+// objectKey converts a value to the object key it names. Every path that
+// reads, writes or deletes a property by a computed key goes through it, so
+// o[1], o["1"] and কি_রিমুভ(o, 1) all find the same property. Only strings and
+// numbers name properties.
+func objectKey(value interface{}) (string, error) {
+	switch value.(type) {
+	case string, int64, float64, *big.Int, *big.Rat:
+		key, _ := stringifyOperand(value)
+		return utils.NormalizeName(key), nil
+	}
+	return "", fmt.Errorf("অব্জেক্ট কী স্ট্রিং বা সংখ্যা হতে হবে, পেয়েছি %s", typeName(value))
+}
+
+// stringifyOperand converts the operand of a string concatenation to text.
+// Booleans and nil concatenate the way দেখাও prints them.
 func stringifyOperand(value interface{}) (string, error) {
 	switch v := value.(type) {
-	case int64, float64, string:
+	case nil:
+		return "nil", nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int64, float64, string, *big.Int:
 		return fmt.Sprintf("%v", v), nil
-	case []rune:
-		return fmt.Sprintf("%v", string(v)), nil
+	case *big.Rat:
+		return formatDecimal(v), nil
 	default:
 		return "", fmt.Errorf("cannot stringify value of type %T", value)
 	}
@@ -846,44 +1729,283 @@ func isTruthy(value interface{}) bool {
 	if num, ok := value.(int); ok {
 		return num != 0
 	}
-	return true // Everything else is considered true
+	return true // Everything else, including functions, is considered true
 }
 
 func isEqual(a, b interface{}) bool {
+	// Numbers compare by value regardless of whether they are int64 or float64
+	if isNumber(a) && isNumber(b) {
+		if result, ok := evaluateDecimal(a, token.Token{Type: token.EQUAL_EQUAL}, b); ok {
+			return result.(bool)
+		}
+		if result, ok := evaluateBig(a, token.Token{Type: token.EQUAL_EQUAL}, b); ok {
+			return result.(bool)
+		}
+		left, _ := toNumber(a)
+		right, _ := toNumber(b)
+		return left == right
+	}
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	// Functions are only equal to themselves, never to another declaration or a value
+	_, aCallable := a.(Callable)
+	_, bCallable := b.(Callable)
+	if aCallable || bCallable {
+		return aCallable && bCallable && a == b
+	}
+	// Arrays and objects compare by their contents, as switch cases do
+	switch a.(type) {
+	case []interface{}, map[string]interface{}:
+		return deepEqual(a, b, make(map[[2]uintptr]bool))
+	}
+	if !reflect.TypeOf(a).Comparable() || !reflect.TypeOf(b).Comparable() {
+		return false
+	}
 	return a == b
 }
 
+// deepEqual compares arrays element by element and objects key by key, falling
+// back to isEqual for everything else. Pairs already being compared are
+// treated as equal so cyclic structures terminate.
+func deepEqual(a, b interface{}, visiting map[[2]uintptr]bool) bool {
+	switch left := a.(type) {
+	case []interface{}:
+		right, ok := b.([]interface{})
+		if !ok || len(left) != len(right) {
+			return false
+		}
+		if len(left) == 0 {
+			return true
+		}
+		pair := [2]uintptr{reflect.ValueOf(left).Pointer(), reflect.ValueOf(right).Pointer()}
+		if visiting[pair] {
+			return true
+		}
+		visiting[pair] = true
+		for index := range left {
+			if !deepEqual(left[index], right[index], visiting) {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}:
+		right, ok := b.(map[string]interface{})
+		if !ok || len(left) != len(right) {
+			return false
+		}
+		pair := [2]uintptr{reflect.ValueOf(left).Pointer(), reflect.ValueOf(right).Pointer()}
+		if visiting[pair] {
+			return true
+		}
+		visiting[pair] = true
+		for key, value := range left {
+			other, exists := right[key]
+			if !exists || !deepEqual(value, other, visiting) {
+				return false
+			}
+		}
+		return true
+	}
+	return isEqual(a, b)
+}
+
+func isNumber(value interface{}) bool {
+	switch value.(type) {
+	case int64, float64, *big.Int, *big.Rat:
+		return true
+	}
+	return false
+}
+
+// getLineNumber returns the source line a node starts on, or 0 for a node
+// the parser did not build.
 func getLineNumber(expr ast.Expr) int {
 	switch e := expr.(type) {
 	case *ast.Binary:
 		return e.Line
-	case *ast.Unary:
+	case *ast.Grouping:
 		return e.Line
 	case *ast.Literal:
 		return e.Line
-	case *ast.Grouping:
+	case *ast.Unary:
 		return e.Line
-	case *ast.VarStmt:
-		return e.Name.Line
 	case *ast.Identifier:
 		return e.Line
+	case *ast.Logical:
+		return e.Line
+	case *ast.Call:
+		return e.Line
+	case *ast.Return:
+		return e.Line
+	case *ast.FunctionExpr:
+		return e.Line
+	case *ast.ArrayLiteral:
+		return e.Line
+	case *ast.ArrayAccess:
+		return e.Line
+	case *ast.ObjectLiteral:
+		return e.Line
+	case *ast.PropertyAccess:
+		return e.Line
+	case *ast.Spread:
+		return e.Line
+	case *ast.ExpressionStatement:
+		return e.Line
+	case *ast.PrintStatement:
+		return e.Line
+	case *ast.VarStmt:
+		return e.Line
+	case *ast.VarListStmt:
+		return e.Line
+	case *ast.AssignmentStmt:
+		return e.Line
+	case *ast.BlockStmt:
+		return e.Line
+	case *ast.IfStmt:
+		return e.Line
+	case *ast.While:
+		return e.Line
+	case *ast.ForStmt:
+		return e.Line
+	case *ast.ForEachStmt:
+		return e.Line
+	case *ast.SwitchStmt:
+		return e.Line
+	case *ast.CaseRange:
+		return e.Line
+	case *ast.TryStmt:
+		return e.Line
+	case *ast.ThrowStmt:
+		return e.Line
 	case *ast.BreakStmt:
 		return e.Line
 	case *ast.ContinueStmt:
 		return e.Line
-
-	// Add cases for other expression types if necessary
+	case *ast.FunctionStmt:
+		return e.Line
+	case *ast.ArrayAssignment:
+		return e.Line
+	case *ast.PropertyAssignment:
+		return e.Line
 	default:
 		return 0 // Return 0 if line number is not available
 	}
 }
 
+// defaultPrecision is the number of significant digits floats are formatted
+// with unless an interpreter sets its own Precision.
+const defaultPrecision = 15
+
 func stringify(value interface{}) string {
+	return stringifyWithPrecision(value, defaultPrecision)
+}
+
+// display formats a value for দেখাও and REPL echoes using the interpreter's
+// Precision.
+func (i *Interpreter) display(value interface{}) string {
+	precision := i.Precision
+	if precision == 0 {
+		precision = defaultPrecision
+	}
+	return stringifyWithPrecision(value, precision)
+}
+
+// printable formats a value the way দেখাও prints it, normalizing Bangla text
+// to NFC.
+func (i *Interpreter) printable(value interface{}) string {
+	return norm.NFC.String(i.display(value))
+}
+
+func stringifyWithPrecision(value interface{}, precision int) string {
 	if value == nil {
 		return "nil"
 	}
-	if valRune, ok := value.([]rune); ok {
-		return string(valRune)
+	switch v := value.(type) {
+	case float64:
+		return formatNumber(v, precision)
+	case *big.Rat:
+		return formatDecimal(v)
+	case []interface{}, map[string]interface{}, Iterable:
+		return stringifyElement(v, precision)
 	}
 	return fmt.Sprintf("%v", value)
 }
+
+// formatNumber prints a float with at most precision significant digits and
+// no trailing zeros. A negative precision prints the shortest representation
+// that reads back as the same float. Whole numbers up to 2^53 are exact, so
+// they always print every digit instead of being rounded into exponent form.
+func formatNumber(value float64, precision int) string {
+	if value == math.Trunc(value) && math.Abs(value) <= utils.MaxSafeInteger {
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+	if precision < 0 {
+		precision = -1
+	}
+	return strconv.FormatFloat(value, 'g', precision, 64)
+}
+
+// stringifyElement formats a value nested inside an array or object the way
+// it would be written as a literal, so strings are quoted and containers are
+// formatted recursively. Object keys are sorted to keep the output stable.
+// A container that contains itself prints as [circular] where it recurs.
+func stringifyElement(value interface{}, precision int) string {
+	return stringifyNested(value, precision, make(map[[2]uintptr]bool))
+}
+
+// stringifyNested is stringifyElement for a value inside the containers in
+// enclosing, which are being formatted around it. The same container may
+// appear several times side by side; only one inside itself is circular.
+func stringifyNested(value interface{}, precision int, enclosing map[[2]uintptr]bool) string {
+	switch v := value.(type) {
+	case string:
+		return `"` + v + `"`
+	case Iterable:
+		return stringifyNested(materialize(v), precision, enclosing)
+	case []interface{}:
+		if len(v) == 0 {
+			return "[]"
+		}
+		// Slices sharing a backing array differ by length
+		identity := [2]uintptr{reflect.ValueOf(v).Pointer(), uintptr(len(v))}
+		if enclosing[identity] {
+			return "[circular]"
+		}
+		enclosing[identity] = true
+		defer delete(enclosing, identity)
+
+		parts := make([]string, len(v))
+		for index, element := range v {
+			parts[index] = stringifyNested(element, precision, enclosing)
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	case map[string]interface{}:
+		if len(v) == 0 {
+			return "{}"
+		}
+		identity := [2]uintptr{reflect.ValueOf(v).Pointer(), 0}
+		if enclosing[identity] {
+			return "[circular]"
+		}
+		enclosing[identity] = true
+		defer delete(enclosing, identity)
+
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		parts := make([]string, len(keys))
+		for index, key := range keys {
+			// Keys that could not be written bare are quoted, as in a literal
+			name := key
+			if !lexer.IsIdentifier(key) {
+				name = `"` + key + `"`
+			}
+			parts[index] = name + ": " + stringifyNested(v[key], precision, enclosing)
+		}
+		return "{" + strings.Join(parts, ", ") + "}"
+	}
+	return stringifyWithPrecision(value, precision)
+}
@@ -2,8 +2,10 @@ package interpreter
 
 import (
 	"bytes"
+	"errors"
 	"io"
 	"math"
+	"math/big"
 	"os"
 	"reflect"
 	"strconv"
@@ -45,6 +47,22 @@ func CaptureStderr(f func()) string {
 	return buf.String()
 }
 
+// CaptureStdout captures anything written to os.Stdout during the execution of the provided function.
+func CaptureStdout(f func()) string {
+	r, w, _ := os.Pipe()
+	oldStdout := os.Stdout
+	os.Stdout = w
+
+	f()
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	io.Copy(&buf, r)
+	return buf.String()
+}
+
 // Helper function to convert both int64 and float64 to float64 for comparison
 func toFloat(val interface{}) interface{} {
 	switch v := val.(type) {
@@ -77,6 +95,16 @@ func TestEvalExpression(t *testing.T) {
 		{"Left Shift", "2 << 1;", int64(4), ""},
 		{"Right Shift", "8 >> 2;", int64(2), ""},
 		{"Power", "3 ** 4;", int64(81), ""},
+		{"Power is right associative", "2 ** 3 ** 2;", int64(512), ""},
+		{"Power right associative is 512", "2 ** 3 ** 2 == 512;", true, ""},
+		{"Unary minus binds tighter than power", "-2 ** 2;", int64(4), ""},
+		{"Bitwise AND with float", "5.5 & 2;", nil, "বিটওয়াইজ অপারেটর শুধু পূর্ণসংখ্যায় কাজ করে, পেয়েছি 5.5"},
+		{"Bitwise OR with integral float", "4.0 | 1;", int64(5), ""},
+		{"Large left shift", "1 << 64;", new(big.Int).Lsh(big.NewInt(1), 64), ""},
+		{"Large right shift", "8 >> 100;", int64(0), ""},
+		{"Negative shift", "1 << -1;", nil, "Shift amount must be non-negative."},
+		{"Bitwise NOT on bitwise result", "~(5 & 3);", int64(-2), ""},
+		{"Bitwise NOT on float", "~2.5;", nil, "বিটওয়াইজ অপারেটর শুধু পূর্ণসংখ্যায় কাজ করে, পেয়েছি 2.5"},
 
 		// // Complex expressions involving bitwise and arithmetic
 		{"Complex Bitwise and Arithmetic", "(5 & 3) + (8 >> 2) * 3 - (3 ** 2);", float64(1 + 6 - 9), ""},
@@ -97,8 +125,22 @@ func TestEvalExpression(t *testing.T) {
 		{"Equality false", "4 == 5;", false, ""},
 		{"Not equal true", "4 != 5;", true, ""},
 		{"Not equal false", "4 != 4;", false, ""},
+		{"Int and float equality", "(4 & 5) == 4.0;", true, ""},
+		{"Int and float inequality", "(4 & 5) != 4.0;", false, ""},
+		{"Zero int and float equality", "(1 & 2) == 0.0;", true, ""},
+		{"Number and numeric string equality", "5 == \"5\";", false, ""},
+		{"Number and boolean equality", "1 == সত্য;", false, ""},
+		{"String equality", "\"ab\" == \"ab\";", true, ""},
+		{"String inequality", "\"ab\" != \"ac\";", true, ""},
+		{"Arrays with the same elements are equal", "[1] == [1];", true, ""},
 		{"Grouping and precedence", "(1 + 2) * 3;", 9.0, ""},
 		{"Unary minus", "-5;", -5.0, ""},
+		{"Double negation", "- -5;", 5.0, ""},
+		{"Unary plus", "+5;", 5.0, ""},
+		{"Unary plus keeps the sign", "+-3;", -3.0, ""},
+		{"Unary plus after binary plus", "2 + +1;", 3.0, ""},
+		{"Unary plus on a string", "+\"x\";", nil, `'+' অপারেটরের অপারেন্ড সংখ্যা হতে হবে, পেয়েছি স্ট্রিং`},
+		{"Unary minus on a string", "-\"x\";", nil, `'-' অপারেটরের অপারেন্ড সংখ্যা হতে হবে, পেয়েছি স্ট্রিং`},
 		{"Unary bang true", "!সত্য;", false, ""},
 		{"Unary bang false", "!মিথ্যা;", true, ""},
 		{"Unary bang number", "!0;", true, ""},
@@ -120,8 +162,8 @@ func TestEvalExpression(t *testing.T) {
 
 		// // // Nil-related expressions
 		// {"Nil equality", "nil == nil;", true, ""},
-		{"Nil addition", "nil + nil;", nil, "Operands must be numbers or strings."},
-		{"Nil in comparison", "nil > 1;", nil, "Left operand must be a number."},
+		{"Nil addition", "nil + nil;", nil, "'+' অপারেটর nil ও nil যোগ করতে পারে না"},
+		{"Nil in comparison", "nil > 1;", nil, "'>' অপারেটরের বাম অপারেন্ড সংখ্যা হতে হবে, পেয়েছি nil"},
 
 		// // Complex arithmetic expressions
 		{"Complex arithmetic 1", "((2 + 3) * 4 - 5) / 2;", 7.5, ""},
@@ -129,7 +171,7 @@ func TestEvalExpression(t *testing.T) {
 
 		// // Error cases
 		{"Division by zero", "10 / 0;", nil, "Division by zero."},
-		{"Invalid comparison with nil", "5 > nil;", nil, "Right operand must be a number."},
+		{"Invalid comparison with nil", "5 > nil;", nil, "'>' অপারেটরের ডান অপারেন্ড সংখ্যা হতে হবে, পেয়েছি nil"},
 
 		// String + number -> Should concatenate after converting number to string
 		{"String and number concatenation", "\"Number: \" + 42;", "Number: 42", ""},
@@ -141,11 +183,16 @@ func TestEvalExpression(t *testing.T) {
 		// Number + string + number -> Should concatenate all
 		{"Number + string + number", "123 + \" + \" + 456;", "123 + 456", ""},
 
+		// Booleans and nil concatenate the way দেখাও prints them
+		{"String and boolean concatenation", "\"foo\" + সত্য;", "footrue", ""},
+		{"Boolean and string concatenation", "মিথ্যা + \"foo\";", "falsefoo", ""},
+		{"String and nil concatenation", "\"foo\" + nil;", "foonil", ""},
+		{"Nil and string concatenation", "nil + \"foo\";", "nilfoo", ""},
+
 		// // Invalid operations
-		{"Invalid addition of string and boolean", "\"foo\" + সত্য;", nil, "Right operand must be a string or number."},
-		{"Invalid addition of boolean and string", "সত্য + \"foo\";", nil, "Operands must be numbers or strings."},
-		{"Invalid addition of string and nil", "\"foo\" + nil;", nil, "Right operand must be a string or number."},
-		{"Invalid addition of number and nil", "42 + nil;", nil, "Operands must be numbers or strings."},
+		{"Invalid addition of number and nil", "42 + nil;", nil, "'+' অপারেটর সংখ্যা ও nil যোগ করতে পারে না"},
+		{"Invalid addition of boolean and number", "সত্য + 1;", nil, "'+' অপারেটর বুলিয়ান ও সংখ্যা যোগ করতে পারে না"},
+		{"Invalid addition of string and array", "\"foo\" + [1];", nil, "'+' অপারেটরের ডান অপারেন্ড স্ট্রিং বা সংখ্যা হতে হবে, পেয়েছি অ্যারে"},
 	}
 
 	for _, tt := range tests {
@@ -160,7 +207,7 @@ func TestEvalExpression(t *testing.T) {
 			capturedErr := CaptureStderr(func() {
 				// Lexical analysis
 				scanner := lexer.NewScanner([]rune(tt.input))
-				tokens := scanner.ScanTokens()
+				tokens, _ := scanner.ScanTokens()
 
 				// Check for lexical errors
 				if utils.HadError {
@@ -216,13 +263,16 @@ func TestEvalUnary(t *testing.T) {
 		errorMsg string
 	}{
 		{"Negate Number", token.MINUS, 5.0, -5.0, ""},
-		{"Negate Non-Number", token.MINUS, "hello", nil, `expected a number, got string "hello"`},
+		{"Negate Non-Number", token.MINUS, "hello", nil, `'-' অপারেটরের অপারেন্ড সংখ্যা হতে হবে, পেয়েছি স্ট্রিং`},
+		{"Plus Number", token.PLUS, 5.0, 5.0, ""},
+		{"Plus Non-Number", token.PLUS, "hello", nil, `'+' অপারেটরের অপারেন্ড সংখ্যা হতে হবে, পেয়েছি স্ট্রিং`},
+		{"Unknown Operator", token.STAR, 5.0, nil, "Unknown unary operator '*'. Expected one of '!', '-', '+' or '~'."},
 		{"Logical Not True", token.BANG, true, false, ""},
 		{"Logical Not False", token.BANG, false, true, ""},
 		{"Logical Not Nil", token.BANG, nil, true, ""},
 		{"Logical Not Number", token.BANG, 42.0, false, ""},
 		{"NOT operator", token.NOT, int64(1), -2, ""},
-		{"NOT operator on string", token.NOT, "hello", nil, `expected an integer, got string "hello"`},
+		{"NOT operator on string", token.NOT, "hello", nil, `'~' অপারেটরের অপারেন্ড পূর্ণসংখ্যা হতে হবে, পেয়েছি স্ট্রিং`},
 	}
 
 	for _, tt := range tests {
@@ -295,10 +345,13 @@ func TestEvalBinary(t *testing.T) {
 		{"Less Than", 2.0, token.LESS, 3.0, true, ""},
 		{"Equality True", 42.0, token.EQUAL_EQUAL, 42.0, true, ""},
 		{"Equality False", 42.0, token.EQUAL_EQUAL, 43.0, false, ""},
+		{"Equality Int and Float", int64(5), token.EQUAL_EQUAL, 5.0, true, ""},
+		{"Equality Zero Int and Float", int64(0), token.EQUAL_EQUAL, 0.0, true, ""},
+		{"Inequality Int and Float", int64(5), token.BANG_EQUAL, 5.5, true, ""},
 		{"Inequality", "foo", token.BANG_EQUAL, "bar", true, ""},
-		{"Comparison with Nil", nil, token.GREATER, 5.0, nil, "Left operand must be a number."},
-		{"Addition with Nil", nil, token.PLUS, 5.0, nil, "Operands must be numbers or strings."},
-		{"Addition Nil + Nil", nil, token.PLUS, nil, nil, "Operands must be numbers or strings."},
+		{"Comparison with Nil", nil, token.GREATER, 5.0, nil, "'>' অপারেটরের বাম অপারেন্ড সংখ্যা হতে হবে, পেয়েছি nil"},
+		{"Addition with Nil", nil, token.PLUS, 5.0, nil, "'+' অপারেটর nil ও সংখ্যা যোগ করতে পারে না"},
+		{"Addition Nil + Nil", nil, token.PLUS, nil, nil, "'+' অপারেটর nil ও nil যোগ করতে পারে না"},
 	}
 
 	for _, tt := range tests {
@@ -366,6 +419,8 @@ func tokenTypeToLexeme(tokenType token.TokenType) string {
 		return "/"
 	case token.BANG:
 		return "!"
+	case token.NOT:
+		return "~"
 	case token.EQUAL_EQUAL:
 		return "=="
 	case token.BANG_EQUAL:
@@ -382,3 +437,2477 @@ func tokenTypeToLexeme(tokenType token.TokenType) string {
 		return ""
 	}
 }
+
+func TestStrictMode(t *testing.T) {
+	tests := []struct {
+		name     string
+		left     interface{}
+		operator token.TokenType
+		right    interface{}
+		strict   bool
+		expected interface{}
+		errorMsg string
+	}{
+		{"Lenient string times number", "5", token.STAR, 3.0, false, 15.0, ""},
+		{"Lenient string comparison", "5", token.LESS, 10.0, false, true, ""},
+		{"Lenient string concatenation", "5", token.PLUS, 3.0, false, "53", ""},
+		{"Strict string times number", "5", token.STAR, 3.0, true, nil, "'*' অপারেটরের বাম অপারেন্ড সংখ্যা হতে হবে, পেয়েছি স্ট্রিং"},
+		{"Strict number minus string", 5.0, token.MINUS, "3", true, nil, "'-' অপারেটরের ডান অপারেন্ড সংখ্যা হতে হবে, পেয়েছি স্ট্রিং"},
+		{"Strict string comparison", "5", token.LESS, 10.0, true, nil, "'<' অপারেটরের বাম অপারেন্ড সংখ্যা হতে হবে, পেয়েছি স্ট্রিং"},
+		{"Strict string concatenation", "5", token.PLUS, 3.0, true, "53", ""},
+		{"Strict numbers", 5.0, token.STAR, 3.0, true, 15.0, ""},
+	}
+
+	for _, tt := range tests {
+		var output interface{}
+		t.Run(tt.name, func(t *testing.T) {
+			utils.HadRuntimeError = false
+
+			capturedErr := CaptureStderr(func() {
+				operatorToken := token.Token{
+					Type:   tt.operator,
+					Lexeme: tokenTypeToLexeme(tt.operator),
+					Line:   1,
+				}
+				expr := &ast.Binary{
+					Operator: operatorToken,
+					Left:     &ast.Literal{Value: tt.left},
+					Right:    &ast.Literal{Value: tt.right},
+				}
+
+				interpreter := NewInterpreter()
+				interpreter.StrictMode = tt.strict
+				results := interpreter.Interpret([]ast.Stmt{expr}, false)
+				if len(results) > 0 {
+					output = results[0]
+				}
+			})
+
+			capturedErr = strings.Split(capturedErr, "\n")[0]
+
+			if tt.errorMsg != "" {
+				if capturedErr != tt.errorMsg {
+					t.Fatalf("Expected runtime error '%s', but got '%s'.", tt.errorMsg, capturedErr)
+				}
+				return
+			}
+			if utils.HadRuntimeError {
+				t.Fatalf("Unexpected runtime error: %s", capturedErr)
+			}
+			if !reflect.DeepEqual(toFloat(tt.expected), toFloat(output)) {
+				t.Fatalf("Expected %v, got %v", tt.expected, output)
+			}
+		})
+	}
+}
+
+func TestStrictModeExplicitConversion(t *testing.T) {
+	utils.HadError = false
+	utils.HadRuntimeError = false
+
+	var output interface{}
+	capturedErr := CaptureStderr(func() {
+		tokens, _ := lexer.NewScanner([]rune(`সংখ্যায়("৫") * 3;`)).ScanTokens()
+		stmts, err := parser.NewParser(tokens).Parse()
+		if err != nil {
+			t.Fatalf("Parser error: %v", err)
+		}
+
+		interpreter := NewInterpreter()
+		interpreter.StrictMode = true
+		results := interpreter.Interpret(stmts, false)
+		if len(results) > 0 {
+			output = results[0]
+		}
+	})
+
+	if utils.HadRuntimeError {
+		t.Fatalf("Unexpected runtime error: %s", capturedErr)
+	}
+	if output != 15.0 {
+		t.Fatalf("Expected 15, got %v", output)
+	}
+}
+
+// runSource scans, parses and interprets the input, returning the value of
+// the last statement along with anything written to stderr.
+func runSource(t *testing.T, input string) (interface{}, string) {
+	t.Helper()
+	utils.HadError = false
+	utils.HadRuntimeError = false
+
+	var output interface{}
+	capturedErr := CaptureStderr(func() {
+		tokens, _ := lexer.NewScanner([]rune(input)).ScanTokens()
+		stmts, err := parser.NewParser(tokens).Parse()
+		if err != nil || utils.HadError {
+			return
+		}
+
+		results := NewInterpreter().Interpret(stmts, false)
+		if len(results) > 0 {
+			output = results[len(results)-1]
+		}
+	})
+	return output, capturedErr
+}
+
+type sourceTest struct {
+	name     string
+	input    string
+	expected interface{}
+	errorMsg string
+}
+
+// runSourceTests runs each program and compares the value of its last
+// statement, or the first line of stderr when an error is expected.
+func runSourceTests(t *testing.T, tests []sourceTest) {
+	t.Helper()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, capturedErr := runSource(t, tt.input)
+			if tt.errorMsg != "" {
+				if firstLine := strings.Split(capturedErr, "\n")[0]; firstLine != tt.errorMsg {
+					t.Fatalf("Expected error %q, got %q", tt.errorMsg, firstLine)
+				}
+				return
+			}
+			if utils.HadError || utils.HadRuntimeError {
+				t.Fatalf("Unexpected error: %s", capturedErr)
+			}
+			if !reflect.DeepEqual(output, tt.expected) {
+				t.Fatalf("Expected %v, got %v", tt.expected, output)
+			}
+		})
+	}
+}
+
+// outputOptions are the interpreter settings runOutputTests runs with. The
+// zero value is the default interpreter.
+type outputOptions struct {
+	Precision   int
+	DecimalMode bool
+}
+
+type outputTest struct {
+	name     string
+	input    string
+	expected string
+}
+
+// runOutputTests runs each program with the given settings and compares
+// everything it printed to stdout.
+func runOutputTests(t *testing.T, options outputOptions, tests []outputTest) {
+	t.Helper()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			utils.HadError = false
+			utils.HadRuntimeError = false
+
+			var stdout bytes.Buffer
+			i := NewInterpreter()
+			i.Precision = options.Precision
+			i.DecimalMode = options.DecimalMode
+			i.Stdout = &stdout
+
+			capturedErr := CaptureStderr(func() {
+				tokens, _ := lexer.NewScanner([]rune(tt.input)).ScanTokens()
+				stmts, err := parser.NewParser(tokens).Parse()
+				if err != nil || utils.HadError {
+					return
+				}
+				i.Interpret(stmts, false)
+			})
+			if utils.HadError || utils.HadRuntimeError {
+				t.Fatalf("Unexpected error: %s", capturedErr)
+			}
+			if stdout.String() != tt.expected {
+				t.Fatalf("Expected %q, got %q", tt.expected, stdout.String())
+			}
+		})
+	}
+}
+
+func TestControlFlowPropagation(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected interface{}
+	}{
+		{
+			"Continue inside if inside for body",
+			`ধরি s = 0;
+			ফর (ধরি i = 0; i < 5; i = i + 1) {
+				যদি (i == 2) { চালিয়ে_যাও; }
+				s = s + i;
+			}
+			s;`,
+			8.0,
+		},
+		{
+			"Continue nested two levels deep",
+			`ধরি s = 0;
+			ধরি n = 0;
+			ফর (ধরি i = 0; i < 6; i = i + 1) {
+				n = n + 1;
+				{
+					যদি (i % 2 == 0) {
+						যদি (i > 0) { চালিয়ে_যাও; }
+					}
+				}
+				s = s + i;
+			}
+			[s, n];`,
+			[]interface{}{9.0, 6.0},
+		},
+		{
+			"Break inside else inside for body",
+			`ধরি s = 0;
+			ফর (ধরি i = 0; i < 10; i = i + 1) {
+				যদি (i < 3) { s = s + i; } নাহয় { থামো; }
+			}
+			s;`,
+			3.0,
+		},
+		{
+			"Continue inside while body",
+			`ধরি i = 0;
+			ধরি s = 0;
+			যতক্ষণ (i < 5) {
+				i = i + 1;
+				যদি (i == 3) { চালিয়ে_যাও; }
+				s = s + i;
+			}
+			s;`,
+			12.0,
+		},
+		{
+			"Return from inside while loop",
+			`ফাংশন first() {
+				ধরি i = 0;
+				যতক্ষণ (সত্য) {
+					যদি (i == 4) { ফেরত i; }
+					i = i + 1;
+				}
+			}
+			first();`,
+			4.0,
+		},
+		{
+			"Return from nested for loops",
+			`ফাংশন find() {
+				ফর (ধরি i = 0; i < 3; i = i + 1) {
+					ফর (ধরি j = 0; j < 3; j = j + 1) {
+						যদি (i * j == 2) { ফেরত [i, j]; }
+					}
+				}
+				ফেরত nil;
+			}
+			find();`,
+			[]interface{}{1.0, 2.0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, capturedErr := runSource(t, tt.input)
+			if utils.HadError || utils.HadRuntimeError {
+				t.Fatalf("Unexpected error: %s", capturedErr)
+			}
+			if !reflect.DeepEqual(output, tt.expected) {
+				t.Fatalf("Expected %v, got %v", tt.expected, output)
+			}
+		})
+	}
+}
+
+func TestPrintStatement(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Single value", `দেখাও 42;`, "42\n"},
+		{"Single string", `দেখাও "হ্যালো";`, "হ্যালো\n"},
+		{"Multiple values", `দেখাও 1, "দুই", সত্য, nil;`, "1 দুই true nil\n"},
+		{"Multiple expressions", `ধরি a = 2; দেখাও a, a * 3, "a" + a;`, "2 6 a2\n"},
+		{"Multi-line array literal", "দেখাও [\n\t1,\n\t2\n];", "[1, 2]\n"},
+		{"Multi-line object property", "দেখাও {\n\ta: 1\n}.a;", "1\n"},
+		{"Array of strings", `দেখাও ["ক", "খ" + "গ"];`, "[\"ক\", \"খগ\"]\n"},
+		{"Nested arrays", `দেখাও [1, ["a", [সত্য, nil]], []];`, "[1, [\"a\", [true, nil]], []]\n"},
+		{"Object", `দেখাও {নাম: "বর্ণ", দাম: 2.5};`, "{দাম: 2.5, নাম: \"বর্ণ\"}\n"},
+		{"Nested object", `দেখাও {b: [1, {c: "x"}], a: {}};`, "{a: {}, b: [1, {c: \"x\"}]}\n"},
+		{"Keys that are not names", `দেখাও {"x y": 1, "১ম": 2, "যদি": 3, ক: 4};`, "{\"x y\": 1, ক: 4, \"যদি\": 3, \"১ম\": 2}\n"},
+		{"Object with function", `ফাংশন f() {} দেখাও {f: f, g: লেন};`, "{f: <function f()>, g: <native fn len/1>}\n"},
+		{"Function signature", `ফাংশন add(a, b) { ফেরত a + b; } দেখাও add;`, "<function add(a, b)>\n"},
+		{"Anonymous function signature", `দেখাও ফাংশন(a, b) => a;`, "<function (a, b)>\n"},
+		{"Native signatures", `দেখাও ভাঙো, সর্বনিম্ন, ক্লক;`, "<native fn split/2-3> <native fn min/1+> <native fn clock/0>\n"},
+		{"String value stays unquoted", `দেখাও "ক", ["ক"];`, "ক [\"ক\"]\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var capturedErr string
+			output := CaptureStdout(func() {
+				_, capturedErr = runSource(t, tt.input)
+			})
+			if utils.HadError || utils.HadRuntimeError {
+				t.Fatalf("Unexpected error: %s", capturedErr)
+			}
+			if output != tt.expected {
+				t.Fatalf("Expected %q, got %q", tt.expected, output)
+			}
+		})
+	}
+}
+
+func TestSpread(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{
+			"Spread into function call",
+			`ফাংশন add(a, b, c) { ফেরত a + b + c; }
+			ধরি args = [1, 2, 3];
+			add(...args);`,
+			6.0, "",
+		},
+		{
+			"Spread mixed with arguments",
+			`ফাংশন add(a, b, c) { ফেরত a + b + c; }
+			add(1, ...[2, 3]);`,
+			6.0, "",
+		},
+		{
+			"Spread arrays into array literal",
+			`ধরি a = [1, 2];
+			ধরি b = [3];
+			[...a, ...b, 5];`,
+			[]interface{}{1.0, 2.0, 3.0, 5.0}, "",
+		},
+		{
+			"Spread empty array",
+			`[...[], 1];`,
+			[]interface{}{1.0}, "",
+		},
+		{
+			"Spread arity mismatch",
+			`ফাংশন add(a, b) { ফেরত a + b; }
+			add(...[1, 2, 3]);`,
+			nil, "Expected 2 arguments but got 3.",
+		},
+		{
+			"Spread non-array",
+			`[...5];`,
+			nil, "স্প্রেড শুধু অ্যারেতে কাজ করে, পেয়েছি সংখ্যা",
+		},
+	})
+}
+
+func TestFlattenAndZip(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{"Flatten one level", `সমতল([1, [2, 3], [[4]]]);`, []interface{}{1.0, 2.0, 3.0, []interface{}{4.0}}, ""},
+		{"Flatten with depth", `সমতল([1, [2, [3, [4]]]], 2);`, []interface{}{1.0, 2.0, 3.0, []interface{}{4.0}}, ""},
+		{"Flatten with zero depth", `সমতল([1, [2]], 0);`, []interface{}{1.0, []interface{}{2.0}}, ""},
+		{"Flatten empty arrays", `সমতল([[], [], 1]);`, []interface{}{1.0}, ""},
+		{"Flatten does not mutate input", `ধরি a = [[1], 2]; সমতল(a); a;`, []interface{}{[]interface{}{1.0}, 2.0}, ""},
+		{"Flatten non-array", `সমতল(5);`, nil, "সমতল শুধু অ্যারেতে কাজ করে, পেয়েছি সংখ্যা"},
+		{"Flatten negative depth", `সমতল([1], -1);`, nil, "সমতল-এর দ্বিতীয় আর্গুমেন্ট অঋণাত্মক পূর্ণসংখ্যা হতে হবে, পেয়েছি -1"},
+		{"Zip equal lengths", `জিপ([1, 2], ["a", "b"]);`, []interface{}{[]interface{}{1.0, "a"}, []interface{}{2.0, "b"}}, ""},
+		{"Zip ragged inputs", `জিপ([1, 2, 3], [4]);`, []interface{}{[]interface{}{1.0, 4.0}}, ""},
+		{"Zip empty input", `জিপ([], [1, 2]);`, []interface{}{}, ""},
+		{"Zip non-array", `জিপ([1], "a");`, nil, "জিপ শুধু অ্যারেতে কাজ করে, পেয়েছি স্ট্রিং"},
+	})
+}
+
+func TestClampSignHypot(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{"Clamp inside range", `সীমাবদ্ধ(5, 0, 10);`, 5.0, ""},
+		{"Clamp below range", `সীমাবদ্ধ(-5, 0, 10);`, 0.0, ""},
+		{"Clamp above range", `সীমাবদ্ধ(15, 0, 10);`, 10.0, ""},
+		{"Clamp at boundary", `সীমাবদ্ধ(10, 0, 10);`, 10.0, ""},
+		{"Clamp negative range", `সীমাবদ্ধ(0, -3, -1);`, -1.0, ""},
+		{"Clamp empty range", `সীমাবদ্ধ(4, 4, 4);`, 4.0, ""},
+		{"Clamp inverted bounds", `সীমাবদ্ধ(5, 10, 0);`, nil, "সীমাবদ্ধ-এর নিচের সীমা উপরের সীমার চেয়ে বড় হতে পারে না"},
+		{"Sign positive", `চিহ্ন(৩.৫);`, 1.0, ""},
+		{"Sign negative", `চিহ্ন(-2);`, -1.0, ""},
+		{"Sign zero", `চিহ্ন(0);`, 0.0, ""},
+		{"Sign non-number", `চিহ্ন(nil);`, nil, "চিহ্ন শুধু সংখ্যায় কাজ করে, পেয়েছি nil"},
+		{"Hypot", `হাইপোট(3, 4);`, 5.0, ""},
+		{"Hypot negatives", `হাইপোট(-5, -12);`, 13.0, ""},
+		{"Hypot zero", `হাইপোট(0, 0);`, 0.0, ""},
+	})
+}
+
+func TestReplace(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{"Replace all occurrences", `প্রতিস্থাপন("a-b-c", "-", "+");`, "a+b+c", ""},
+		{"Replace Bangla text", `প্রতিস্থাপন("আমি ভাত খাই, তুমি ভাত খাও", "ভাত", "রুটি");`, "আমি রুটি খাই, তুমি রুটি খাও", ""},
+		{"Replace overlapping pattern", `প্রতিস্থাপন("aaaa", "aa", "b");`, "bb", ""},
+		{"Replace odd overlapping pattern", `প্রতিস্থাপন("aaa", "aa", "b");`, "ba", ""},
+		{"Replace missing substring", `প্রতিস্থাপন("abc", "x", "y");`, "abc", ""},
+		{"Replace with empty string", `প্রতিস্থাপন("a b c", " ", "");`, "abc", ""},
+		{"Replace first occurrence", `প্রতিস্থাপন_প্রথম("ভাত ভাত", "ভাত", "রুটি");`, "রুটি ভাত", ""},
+		{"Replace empty substring", `প্রতিস্থাপন("abc", "", "x");`, nil, "replace_all function cannot replace an empty substring"},
+		{"Replace first empty substring", `প্রতিস্থাপন_প্রথম("abc", "", "x");`, nil, "replace_first function cannot replace an empty substring"},
+		{"Replace non-string", `প্রতিস্থাপন(5, "5", "6");`, nil, "প্রতিস্থাপন শুধু স্ট্রিংয়ে কাজ করে, পেয়েছি সংখ্যা"},
+	})
+}
+
+func TestPrefixSuffixAndTrim(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{"Starts with", `দিয়ে_শুরু("বাংলাদেশ", "বাংলা");`, true, ""},
+		{"Does not start with", `দিয়ে_শুরু("বাংলাদেশ", "দেশ");`, false, ""},
+		{"Starts with empty prefix", `দিয়ে_শুরু("abc", "");`, true, ""},
+		{"Empty string starts with empty prefix", `দিয়ে_শুরু("", "");`, true, ""},
+		{"Ends with", `দিয়ে_শেষ("বাংলাদেশ", "দেশ");`, true, ""},
+		{"Does not end with", `দিয়ে_শেষ("বাংলাদেশ", "বাংলা");`, false, ""},
+		{"Ends with empty suffix", `দিয়ে_শেষ("", "");`, true, ""},
+		{"Ends with on non-string", `দিয়ে_শেষ(5, "5");`, nil, "দিয়ে_শেষ শুধু স্ট্রিংয়ে কাজ করে, পেয়েছি সংখ্যা"},
+		{"Trim start", "শুরু_ছাঁটো(\" \t হ্যালো  \");", "হ্যালো  ", ""},
+		{"Trim end", "শেষ_ছাঁটো(\"  হ্যালো \n\");", "  হ্যালো", ""},
+		{"Trim empty string", `শুরু_ছাঁটো("");`, "", ""},
+		{"Trim only whitespace", `শেষ_ছাঁটো("   ");`, "", ""},
+	})
+}
+
+func TestDeepCopy(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{"Copy scalar", `কপি(5);`, 5.0, ""},
+		{"Copy array is independent", `ধরি a = [1, [2, 3]]; ধরি b = কপি(a); b[1][0] = 9; a;`, []interface{}{1.0, []interface{}{2.0, 3.0}}, ""},
+		{"Copy array keeps values", `ধরি a = [1, [2, 3]]; ধরি b = কপি(a); b[1][0] = 9; b;`, []interface{}{1.0, []interface{}{9.0, 3.0}}, ""},
+		{"Copy object is independent", `ধরি o = {a: {b: 1}}; ধরি c = কপি(o); c.a.b = 2; o.a.b;`, 1.0, ""},
+		{"Copy object keeps values", `ধরি o = {a: {b: 1}, list: [1]}; ধরি c = কপি(o); c.list[0] = 5; [c.a.b, c.list[0], o.list[0]];`, []interface{}{1.0, 5.0, 1.0}, ""},
+		{"Copy cyclic object", `ধরি o = {name: "x"}; o.self = o; ধরি c = কপি(o); c.name = "y"; [o.name == "x", c.self.name == "y"];`, []interface{}{true, true}, ""},
+	})
+}
+
+func TestEntries(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{"Entries of object", `এন্ট্রি({খ: 2, ক: "এক"});`, []interface{}{[]interface{}{"ক", "এক"}, []interface{}{"খ", 2.0}}, ""},
+		{"Entries of empty object", `এন্ট্রি({});`, []interface{}{}, ""},
+		{"Entries of non-object", `এন্ট্রি([1]);`, nil, "এন্ট্রি শুধু অব্জেক্টে কাজ করে, পেয়েছি অ্যারে"},
+		{"Object from entries", `এন্ট্রি_থেকে([["a", 1], ["b", [2]]]);`, map[string]interface{}{"a": 1.0, "b": []interface{}{2.0}}, ""},
+		{"Object from entries keeps last duplicate", `এন্ট্রি_থেকে([["a", 1], ["a", 2]]).a;`, 2.0, ""},
+		{"Round trip", `ধরি o = {x: 1, y: "দুই"}; এন্ট্রি_থেকে(এন্ট্রি(o));`, map[string]interface{}{"x": 1.0, "y": "দুই"}, ""},
+		{"Object from entries with non-string key", `এন্ট্রি_থেকে([[1, 2]]);`, nil, "এন্ট্রি_থেকে-এর 0 নম্বর এন্ট্রির কী স্ট্রিং হতে হবে, পেয়েছি সংখ্যা"},
+		{"Object from entries with malformed pair", `এন্ট্রি_থেকে([["a", 1], ["b"]]);`, nil, "এন্ট্রি_থেকে-এর 1 নম্বর এন্ট্রি [কী, মান] জোড়া হতে হবে, পেয়েছি [\"b\"]"},
+		{"Object from entries with non-array entry", `এন্ট্রি_থেকে(["a"]);`, nil, "এন্ট্রি_থেকে-এর 0 নম্বর এন্ট্রি [কী, মান] জোড়া হতে হবে, পেয়েছি \"a\""},
+	})
+}
+
+func TestSwitch(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{
+			"Concatenated discriminant matches a literal case",
+			`ধরি r = nil; ধরি d = "বাং" + "লা"; সুইচ (d) { ক্ষেত্রে "বাংলা": r = "matched"; নইলে: r = "default"; } r;`,
+			"matched", "",
+		},
+		{
+			"Literal discriminant matches a concatenated case",
+			`ধরি r = nil; সুইচ ("ab") { ক্ষেত্রে "a" + "b": r = "matched"; } r;`,
+			"matched", "",
+		},
+		{
+			"Default before the cases does not run when a case matches",
+			`ধরি r = []; সুইচ ("খ") { নইলে: r = এড(r, "default"); ক্ষেত্রে "ক": r = এড(r, "ka"); ক্ষেত্রে "খ": r = এড(r, "kha"); } r;`,
+			[]interface{}{"kha"}, "",
+		},
+		{
+			"Default before the cases runs when nothing matches",
+			`ধরি r = []; সুইচ ("গ") { নইলে: r = এড(r, "default"); ক্ষেত্রে "ক": r = এড(r, "ka"); } r;`,
+			[]interface{}{"default"}, "",
+		},
+		{
+			"Default between cases",
+			`ধরি r = nil; সুইচ (2) { ক্ষেত্রে 1: r = "one"; নইলে: r = "default"; ক্ষেত্রে 2: r = "two"; } r;`,
+			"two", "",
+		},
+		{
+			"String does not match a number case",
+			`ধরি r = nil; সুইচ ("1") { ক্ষেত্রে 1: r = "number"; নইলে: r = "default"; } r;`,
+			"default", "",
+		},
+		{
+			"Array discriminant matches array case",
+			`ধরি r = nil;
+			সুইচ ([1, 2]) { ক্ষেত্রে [1]: r = "short"; ক্ষেত্রে [1, 2]: r = "pair"; নইলে: r = "other"; }
+			r;`,
+			"pair", "",
+		},
+		{
+			"Nested object case",
+			`ধরি r = nil;
+			ধরি p = {name: "বর্ণ", tags: ["a", {b: 1}]};
+			সুইচ (p) { ক্ষেত্রে {name: "বর্ণ", tags: ["a"]}: r = 1; ক্ষেত্রে {name: "বর্ণ", tags: ["a", {b: 1}]}: r = 2; }
+			r;`,
+			2.0, "",
+		},
+		{
+			"Array does not match a scalar case",
+			`ধরি r = 0;
+			সুইচ ([1]) { ক্ষেত্রে 1: r = 1; নইলে: r = 2; }
+			r;`,
+			2.0, "",
+		},
+		{
+			"Matching case",
+			`ধরি r = nil;
+			সুইচ (2) { ক্ষেত্রে 1: r = "one"; ক্ষেত্রে 2: r = "two"; নইলে: r = "other"; }
+			r;`,
+			"two", "",
+		},
+		{
+			"Multi-value case",
+			`ফাংশন kind(n) { সুইচ (n) { ক্ষেত্রে 1, 3, 5: ফেরত "odd"; ক্ষেত্রে 2, 4: ফেরত "even"; নইলে: ফেরত "other"; } }
+			[kind(1), kind(4), kind(5), kind(6)];`,
+			[]interface{}{"odd", "even", "odd", "other"}, "",
+		},
+		{
+			"Range case includes both bounds",
+			`ফাংশন grade(n) { সুইচ (n) { ক্ষেত্রে 0..59: ফেরত "F"; ক্ষেত্রে 60..79: ফেরত "B"; ক্ষেত্রে 80..100: ফেরত "A"; নইলে: ফেরত "?"; } }
+			[grade(0), grade(59), grade(59.5), grade(60), grade(100), grade(101)];`,
+			[]interface{}{"F", "F", "?", "B", "A", "?"}, "",
+		},
+		{
+			"Range and values in one case",
+			`ফাংশন f(n) { সুইচ (n) { ক্ষেত্রে -1, 1..3: ফেরত সত্য; নইলে: ফেরত মিথ্যা; } }
+			[f(-1), f(2), f(0)];`,
+			[]interface{}{true, true, false}, "",
+		},
+		{
+			"Range only matches numbers",
+			`ধরি r = "none"; সুইচ ("5") { ক্ষেত্রে 1..10: r = "range"; } r;`,
+			"none", "",
+		},
+		{
+			"Range bounds must be numbers",
+			`সুইচ (1) { ক্ষেত্রে "a".."z": দেখাও 1; }`,
+			nil, "কেস পরিসরের সীমা সংখ্যা হতে হবে, পেয়েছি স্ট্রিং",
+		},
+		{
+			"Cases do not fall through",
+			`ধরি n = 0;
+			সুইচ (1) { ক্ষেত্রে 1: n = n + 1; ক্ষেত্রে 2: n = n + 10; }
+			n;`,
+			1.0, "",
+		},
+		{
+			"Default when no case matches",
+			`ধরি r = nil;
+			সুইচ (5) { ক্ষেত্রে 1: r = "one"; নইলে: r = "other"; }
+			r;`,
+			"other", "",
+		},
+		{
+			"No match without default",
+			`ধরি r = 0;
+			সুইচ (5) { ক্ষেত্রে 1: r = 1; }
+			r;`,
+			0.0, "",
+		},
+		{
+			"Break leaves the switch",
+			`ধরি n = 0;
+			সুইচ (1) { ক্ষেত্রে 1: n = 1; থামো; n = 2; }
+			n;`,
+			1.0, "",
+		},
+		{
+			"Case body has its own scope",
+			`ধরি x = 1;
+			সুইচ (1) { ক্ষেত্রে 1: ধরি x = 2; }
+			x;`,
+			1.0, "",
+		},
+		{
+			"Loop inside switch inside loop",
+			`ধরি log = [];
+			ফর (ধরি i = 0; i < 3; i = i + 1) {
+				সুইচ (i) {
+					ক্ষেত্রে 1:
+						ফর (ধরি j = 0; j < 5; j = j + 1) {
+							যদি (j == 1) { চালিয়ে_যাও; }
+							যদি (j == 3) { থামো; }
+							log = এড(log, [i, j]);
+						}
+						log = এড(log, "after inner loop");
+						থামো;
+					নইলে:
+						যদি (i == 2) { চালিয়ে_যাও; }
+						log = এড(log, i);
+				}
+				log = এড(log, "end " + i);
+			}
+			log;`,
+			[]interface{}{
+				0.0, "end 0",
+				[]interface{}{1.0, 0.0}, []interface{}{1.0, 2.0}, "after inner loop", "end 1",
+			},
+			"",
+		},
+		{
+			"Break outside loop or switch",
+			`সুইচ (1) { ক্ষেত্রে 1: দেখাও 1; } থামো;`,
+			nil, "[line 1] Error at 'থামো': Can't use 'থামো' outside of a loop or switch.",
+		},
+	})
+}
+
+func TestPartial(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{"Bind one of two arguments", `ফাংশন sub(a, b) { ফেরত a - b; } ধরি from10 = আংশিক(sub, 10); from10(3);`, 7.0, ""},
+		{"Bind all arguments", `ফাংশন sub(a, b) { ফেরত a - b; } ধরি f = আংশিক(sub, 10, 4); f();`, 6.0, ""},
+		{"Partial of partial", `ফাংশন add3(a, b, c) { ফেরত a + b + c; } ধরি f = আংশিক(আংশিক(add3, 1), 2); f(3);`, 6.0, ""},
+		{"Partial of variadic native", `ধরি f = আংশিক(সর্বোচ্চ, 7); f(2, 9);`, 9.0, ""},
+		{"Partial checks remaining arity", `ফাংশন sub(a, b) { ফেরত a - b; } ধরি f = আংশিক(sub, 10); f(1, 2);`, nil, "Expected 1 argument but got 2."},
+		{"Too many bound arguments", `ফাংশন id(a) { ফেরত a; } আংশিক(id, 1, 2);`, nil, "cannot bind 2 arguments to a function that takes 1"},
+		{"Non-callable", `আংশিক(5, 1);`, nil, "আংশিক শুধু ফাংশনে কাজ করে, পেয়েছি সংখ্যা"},
+	})
+}
+
+func TestFunctionEquality(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{"Same function through two variables", `ফাংশন f() { ফেরত 1; } ধরি a = f; ধরি b = f; a == b;`, true, ""},
+		{"Separate declarations with the same body", `ফাংশন f() { ফেরত 1; } ফাংশন g() { ফেরত 1; } f == g;`, false, ""},
+		{"Separate declarations are not equal", `ফাংশন f() { ফেরত 1; } ফাংশন g() { ফেরত 1; } f != g;`, true, ""},
+		{"Function and non-function", `ফাংশন f() { ফেরত 1; } [f == 1, f == nil, f == "f", nil == f];`, []interface{}{false, false, false, false}, ""},
+		{"Same native", `লেন == লেন;`, true, ""},
+		{"Different natives", `লেন == এড;`, false, ""},
+		{"Partials are distinct", `ফাংশন f(a, b) { ফেরত a; } ধরি p = আংশিক(f, 1); [p == p, p == আংশিক(f, 1)];`, []interface{}{true, false}, ""},
+		{"Functions are truthy", `ফাংশন f() { ফেরত nil; } ধরি r = "no"; যদি (f) { r = "yes"; } [r == "yes", !লেন];`, []interface{}{true, false}, ""},
+	})
+}
+
+func TestForEach(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{
+			"Iterate a Bangla word by character",
+			`ধরি chars = []; প্রত্যেক (ch ইন "বাংলা") { chars = এড(chars, ch); } chars;`,
+			[]interface{}{"ব", "া", "ং", "ল", "া"}, "",
+		},
+		{
+			"Characters join back into the word",
+			`ধরি word = "হ্যালো"; ধরি joined = ""; প্রত্যেক (ch ইন word) { joined = joined + ch; } joined == word;`,
+			true, "",
+		},
+		{
+			"Iterate a computed string",
+			`ধরি n = 0; প্রত্যেক (ch ইন "ab" + "c") { n = n + 1; } n;`,
+			3.0, "",
+		},
+		{
+			"Iterate an empty string",
+			`ধরি n = 0; প্রত্যেক (ch ইন "") { n = n + 1; } n;`,
+			0.0, "",
+		},
+		{
+			"Iterate an array",
+			`ধরি sum = 0; প্রত্যেক (x ইন [1, 2, 3]) { sum = sum + x; } sum;`,
+			6.0, "",
+		},
+		{
+			"Iterate object keys in sorted order",
+			`ধরি keys = ""; প্রত্যেক (k ইন {b: 2, a: 1}) { keys = keys + k; } keys;`,
+			"ab", "",
+		},
+		{
+			"Break and continue",
+			`ধরি out = []; প্রত্যেক (x ইন [1, 2, 3, 4]) { যদি (x == 2) চালিয়ে_যাও; যদি (x == 4) থামো; out = এড(out, x); } out;`,
+			[]interface{}{1.0, 3.0}, "",
+		},
+		{
+			"Return from inside foreach",
+			`ফাংশন first(s) { প্রত্যেক (ch ইন s) { ফেরত ch; } ফেরত nil; } first("কখ");`,
+			"ক", "",
+		},
+		{
+			"Iterate a number",
+			`প্রত্যেক (x ইন 5) দেখাও x;`,
+			nil, "লুপ শুধু অ্যারে, অব্জেক্ট ও স্ট্রিংয়ের উপর চলে, পেয়েছি সংখ্যা",
+		},
+	})
+}
+
+func TestArityBounds(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{"Fixed arity native", `বর্গমূল(4, 9);`, nil, "Expected 1 argument but got 2."},
+		{"Fixed arity function", `ফাংশন f(a, b) { ফেরত a; } f(1);`, nil, "Expected 2 arguments but got 1."},
+		{"Append needs at least two", `এড([1]);`, nil, "Expected at least 2 arguments but got 1."},
+		{"Append accepts many", `এড([], 1, 2, 3);`, []interface{}{1.0, 2.0, 3.0}, ""},
+		{"Min needs at least one", `সর্বনিম্ন();`, nil, "Expected at least 1 argument but got 0."},
+		{"Max needs at least one", `সর্বোচ্চ();`, nil, "Expected at least 1 argument but got 0."},
+		{"Flatten takes at most two", `সমতল([[1]], 1, 2);`, nil, "Expected at most 2 arguments but got 3."},
+		{"Flatten with depth", `সমতল([[1, [2]]], 1);`, []interface{}{1.0, []interface{}{2.0}}, ""},
+		{"Input takes at most one", `ইনপুট("a", "b");`, nil, "Expected at most 1 argument but got 2."},
+		{"Partial needs a function", `আংশিক();`, nil, "Expected at least 1 argument but got 0."},
+		{"Partial of variadic keeps bounds", `ধরি f = আংশিক(সমতল, [[1]]); f(1, 2);`, nil, "Expected at most 1 argument but got 2."},
+		{"Partial of variadic lowers minimum", `ধরি f = আংশিক(এড, [], 1); f();`, []interface{}{1.0}, ""},
+		{"Spread arguments are counted", `ধরি args = [1, 2, 3]; সমতল(...args);`, nil, "Expected at most 2 arguments but got 3."},
+	})
+}
+
+func TestAssertEqual(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{"Equal numbers", `নিশ্চিত_সমান(1 + 1, 2);`, nil, ""},
+		{"Equal strings", `নিশ্চিত_সমান("ক" + "খ", "কখ");`, nil, ""},
+		{"Equal nested arrays", `নিশ্চিত_সমান([1, [2, {a: 3}]], [1, [2, {a: 3}]]);`, nil, ""},
+		{"Equal cyclic objects", `ধরি a = {}; a.self = a; ধরি b = {}; b.self = b; নিশ্চিত_সমান(a, b);`, nil, ""},
+		{"Mismatch", `নিশ্চিত_সমান(1 + 1, 3);`, nil, "assertion failed: expected 3 but got 2"},
+		{"Array length mismatch", `নিশ্চিত_সমান([1], [1, 2]);`, nil, "assertion failed: expected [1, 2] but got [1]"},
+		{"Object key mismatch", `নিশ্চিত_সমান({a: 1}, {b: 1});`, nil, "assertion failed: expected {b: 1} but got {a: 1}"},
+	})
+}
+
+func TestAssertEqualInTestMode(t *testing.T) {
+	utils.HadError = false
+	utils.HadRuntimeError = false
+
+	var stderr bytes.Buffer
+	i := NewInterpreter()
+	i.TestMode = true
+	i.Stderr = &stderr
+
+	tokens, _ := lexer.NewScanner([]rune("নিশ্চিত_সমান(1, 1);\nনিশ্চিত_সমান(1 + 1, 3);\n\nফাংশন f() {\n  নিশ্চিত_সমান([1], [2]);\n}\nf();")).ScanTokens()
+	stmts, _ := parser.NewParser(tokens).Parse()
+	i.Interpret(stmts, false)
+	if utils.HadRuntimeError {
+		t.Fatalf("Expected failed assertions not to stop the program")
+	}
+	expected := "[line 2] assertion failed: expected 3 but got 2\n[line 5] assertion failed: expected [2] but got [1]\n"
+	if stderr.String() != expected {
+		t.Fatalf("Expected %q, got %q", expected, stderr.String())
+	}
+	if i.AssertionsPassed != 1 || i.AssertionsFailed != 2 {
+		t.Fatalf("Expected 1 passed and 2 failed, got %d and %d", i.AssertionsPassed, i.AssertionsFailed)
+	}
+}
+
+func TestUninitializedVariable(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{"Defaults to nil", `ধরি x; x;`, nil, ""},
+		{"Several default to nil", `ধরি a, b = 2, c; [a, b, c];`, []interface{}{nil, 2.0, nil}, ""},
+		{"Can be assigned later", `ধরি x; x = 5; x;`, 5.0, ""},
+		{"Cannot be redeclared", `ধরি x; ধরি x;`, nil, "Cannot redeclare variable x."},
+	})
+
+	output := CaptureStdout(func() {
+		runSource(t, `ধরি x; দেখাও x;`)
+	})
+	if output != "nil\n" {
+		t.Fatalf("Expected %q, got %q", "nil\n", output)
+	}
+}
+
+func TestInsertAndClear(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{"Insert at front", `ঢুকাও([2, 3], 0, 1);`, []interface{}{1.0, 2.0, 3.0}, ""},
+		{"Insert in middle", `ঢুকাও([1, 3], 1, 2);`, []interface{}{1.0, 2.0, 3.0}, ""},
+		{"Insert at end appends", `ঢুকাও([1, 2], 2, 3);`, []interface{}{1.0, 2.0, 3.0}, ""},
+		{"Insert into empty array", `ঢুকাও([], 0, "ক");`, []interface{}{"ক"}, ""},
+		{"Insert leaves original unchanged", `ধরি a = [1, 3]; ধরি b = ঢুকাও(a, 1, 2); a;`, []interface{}{1.0, 3.0}, ""},
+		{"Insert past end", `ঢুকাও([1], 2, 0);`, nil, "ঢুকাও-এর ইনডেক্স 2 অ্যারের সীমার বাইরে"},
+		{"Insert at negative index", `ঢুকাও([1], -1, 0);`, nil, "ঢুকাও-এর ইনডেক্স -1 অ্যারের সীমার বাইরে"},
+		{"Insert into non-array", `ঢুকাও("ক", 0, 1);`, nil, "ঢুকাও শুধু অ্যারেতে কাজ করে, পেয়েছি স্ট্রিং"},
+		{"Clear array", `ধরি a = [1, 2]; a = পরিষ্কার(a); a;`, []interface{}{}, ""},
+		{"Clear non-array", `পরিষ্কার(5);`, nil, "পরিষ্কার শুধু অ্যারেতে কাজ করে, পেয়েছি সংখ্যা"},
+	})
+}
+
+func TestSliceAndConcat(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{"Slice middle", `স্লাইস([1, 2, 3, 4], 1, 3);`, []interface{}{2.0, 3.0}, ""},
+		{"Slice without end", `স্লাইস([1, 2, 3], 1);`, []interface{}{2.0, 3.0}, ""},
+		{"Slice negative start", `স্লাইস([1, 2, 3], -2);`, []interface{}{2.0, 3.0}, ""},
+		{"Slice negative end", `স্লাইস([1, 2, 3], 0, -1);`, []interface{}{1.0, 2.0}, ""},
+		{"Slice clamps range", `স্লাইস([1, 2], -5, 10);`, []interface{}{1.0, 2.0}, ""},
+		{"Empty slice", `স্লাইস([1, 2, 3], 2, 1);`, []interface{}{}, ""},
+		{"Slice of empty array", `স্লাইস([], 0);`, []interface{}{}, ""},
+		{"Slice does not alias", `ধরি a = [1, 2, 3]; ধরি s = স্লাইস(a, 0, 2); s[0] = 9; a;`, []interface{}{1.0, 2.0, 3.0}, ""},
+		{"Slice non-integer", `স্লাইস([1], 0.5);`, nil, "স্লাইস-এর দ্বিতীয় আর্গুমেন্ট পূর্ণসংখ্যা হতে হবে, পেয়েছি 0.5"},
+		{"Concat three arrays", `সংযুক্ত([1], [2, 3], [4]);`, []interface{}{1.0, 2.0, 3.0, 4.0}, ""},
+		{"Concat empty arrays", `সংযুক্ত([], []);`, []interface{}{}, ""},
+		{"Concat does not alias", `ধরি a = [1]; ধরি c = সংযুক্ত(a, [2]); c[0] = 9; a;`, []interface{}{1.0}, ""},
+		{"Concat non-array", `সংযুক্ত([1], 2);`, nil, "সংযুক্ত-এর 2 নম্বর আর্গুমেন্ট অ্যারে হতে হবে, পেয়েছি সংখ্যা"},
+	})
+}
+
+func TestOptionalChaining(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{"Nil receiver", `ধরি o = nil; o?.name;`, nil, ""},
+		{"Object receiver", `ধরি o = {name: "বর্ণ"}; o?.name;`, "বর্ণ", ""},
+		{"Chained through nil", `ধরি o = {inner: nil}; o?.inner?.name;`, nil, ""},
+		{"Chained through object", `ধরি o = {inner: {name: 1}}; o?.inner?.name;`, 1.0, ""},
+		{"Missing property still errors", `ধরি o = {}; o?.name;`, nil, "Property 'name' does not exist on object 'o'."},
+		{"Wrong type receiver", `ধরি o = 5; o?.name;`, nil, "প্রপার্টি পড়া শুধু অব্জেক্টে কাজ করে, পেয়েছি সংখ্যা"},
+		{"Plain access on nil still errors", `ধরি o = nil; o.name;`, nil, "প্রপার্টি পড়া শুধু অব্জেক্টে কাজ করে, পেয়েছি nil"},
+		{"Optional index on nil", `ধরি a = nil; a?.[0];`, nil, ""},
+		{"Optional index on array", `ধরি a = [1, 2]; a?.[1];`, 2.0, ""},
+		{"Optional index skips index evaluation", `ধরি a = nil; a?.[y];`, nil, ""},
+		{"Optional index on wrong type", `ধরি a = "ক"; a?.[0];`, nil, "ইনডেক্স শুধু অ্যারে ও অব্জেক্টে কাজ করে, পেয়েছি স্ট্রিং"},
+		{"Optional index out of bounds", `ধরি a = [1]; a?.[5];`, nil, "Array index out of bounds."},
+		{"Nil receiver skips the rest of the chain", `ধরি a = nil; a?.b.c;`, nil, ""},
+		{"Skipped chain with index and call", `ধরি a = nil; a?.b[0].c();`, nil, ""},
+		{"Skipped chain after optional index", `ধরি a = nil; a?.[0].b;`, nil, ""},
+		{"Skipped method call", `ধরি a = nil; a?.লেন();`, nil, ""},
+		{"Skipped chain skips arguments", `ধরি a = nil; a?.b.c(y);`, nil, ""},
+		{"Present receiver continues the chain", `ধরি a = {b: {c: 2}}; a?.b.c;`, 2.0, ""},
+		{"Nil later in the chain still errors", `ধরি a = {b: nil}; a?.b.c;`, nil, "প্রপার্টি পড়া শুধু অব্জেক্টে কাজ করে, পেয়েছি nil"},
+		{"Parentheses end the chain", `ধরি a = nil; (a?.b).c;`, nil, "প্রপার্টি পড়া শুধু অব্জেক্টে কাজ করে, পেয়েছি nil"},
+		{"Skipped chain inside an argument", `ধরি a = nil; ধরি o = {c: 3}; ফাংশন f(x) { ফেরত o; } f(a?.b).c;`, 3.0, ""},
+	})
+}
+
+func TestMethodCalls(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{"Array length", `[1, 2].লেন();`, 2, ""},
+		{"Array append", `ধরি a = [1]; a.এড(2);`, []interface{}{1.0, 2.0}, ""},
+		{"Chained array methods", `[1, 2, 3].স্লাইস(1).লেন();`, 2, ""},
+		{"String upper", `"abc".বড়হাতে();`, "ABC", ""},
+		{"String lower on computed string", `("A" + "B").ছোটহাতে();`, "ab", ""},
+		{"String method with arguments", `"a-b".প্রতিস্থাপন("-", "+");`, "a+b", ""},
+		{"Object keys", `ধরি o = {a: 2}; o.অব্জেক্ট_কি();`, []interface{}{"a"}, ""},
+		{"Own property shadows method", `ফাংশন seven() { ফেরত 7; } ধরি o = {কপি: seven}; o.কপি();`, 7.0, ""},
+		{"Own function property", `ফাংশন double(x) { ফেরত x * 2; } ধরি o = {f: double}; o.f(3);`, 6.0, ""},
+		{"Method arity excludes receiver", `[1].লেন(2);`, nil, "Expected 0 arguments but got 1."},
+		{"Unknown array method", `[1].foo();`, nil, "প্রপার্টি পড়া শুধু অব্জেক্টে কাজ করে, পেয়েছি অ্যারে"},
+		{"Unknown object method", `ধরি o = {}; o.foo();`, nil, "Property 'foo' does not exist on object 'o'."},
+		{"Optional method call on nil", `ধরি a = nil; a?.লেন();`, nil, ""},
+	})
+}
+
+func TestObjectBracketAccess(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{"Read string key", `ধরি o = {name: "বর্ণ"}; o["name"];`, "বর্ণ", ""},
+		{"Read computed key", `ধরি o = {ab: 1}; ধরি k = "a" + "b"; o[k];`, 1.0, ""},
+		{"Write string key", `ধরি o = {}; o["x"] = 5; o.x;`, 5.0, ""},
+		{"Overwrite identifier key", `ধরি o = {x: 1}; o["x"] = 2; o.x;`, 2.0, ""},
+		{"Number key is stringified", `ধরি o = {}; o[1] = 10; o["1"];`, 10.0, ""},
+		{"Whole float key", `ধরি o = {}; o["2"] = 20; o[2.0];`, 20.0, ""},
+		{"Missing key", `ধরি o = {}; o["x"];`, nil, "Property 'x' does not exist on object 'o'."},
+		{"Invalid key type", `ধরি o = {}; o[সত্য];`, nil, "অব্জেক্ট কী স্ট্রিং বা সংখ্যা হতে হবে, পেয়েছি বুলিয়ান"},
+		{"Invalid key type on write", `ধরি o = {}; o[nil] = 1;`, nil, "অব্জেক্ট কী স্ট্রিং বা সংখ্যা হতে হবে, পেয়েছি nil"},
+		{"String literal key with a space", `ধরি o = {"full name": "বর্ণ"}; o["full name"];`, "বর্ণ", ""},
+		{"Reserved word as a key", `ধরি o = {"যদি": 1, "class": 5}; o["যদি"] + o.class;`, 6.0, ""},
+		{"Arrays still use integer indices", `ধরি a = [1, 2]; a[1];`, 2.0, ""},
+		{"Arrays reject non-numeric strings", `ধরি a = [1]; a["x"];`, nil, "অ্যারে ইনডেক্স পূর্ণসংখ্যা হতে হবে, পেয়েছি স্ট্রিং"},
+	})
+}
+
+func TestNumericFastPathMatchesGeneralPath(t *testing.T) {
+	huge, _ := new(big.Int).SetString("18446744073709551616", 10)
+	operands := []interface{}{
+		0.0, 1.0, -2.5, 3.0, int64(3), int64(-7),
+		float64(utils.MaxSafeInteger - 1), int64(utils.MaxSafeInteger), int64(1 << 60), int64(1<<60 | 1),
+		huge, new(big.Int).Neg(huge),
+		big.NewRat(1, 10), big.NewRat(-5, 2),
+	}
+	handlers := map[token.TokenType]func(left, right interface{}, operator token.Token) interface{}{
+		token.PLUS:          handleAddition,
+		token.MINUS:         handleArithmetic,
+		token.STAR:          handleArithmetic,
+		token.SLASH:         handleArithmetic,
+		token.LESS:          handleComparison,
+		token.LESS_EQUAL:    handleComparison,
+		token.GREATER:       handleComparison,
+		token.GREATER_EQUAL: handleComparison,
+		token.EQUAL_EQUAL:   handleEquality,
+		token.BANG_EQUAL:    handleEquality,
+	}
+	// Operands the fast path must leave to the big-integer and decimal paths
+	exactOnly := func(value interface{}) bool {
+		_, isDecimal := value.(*big.Rat)
+		return isDecimal || beyondFloat(value)
+	}
+
+	for operatorType, handler := range handlers {
+		operator := token.Token{Type: operatorType}
+		for _, left := range operands {
+			for _, right := range operands {
+				fast, ok := evaluateNumeric(left, operatorType, right)
+				if exactOnly(left) || exactOnly(right) {
+					if ok {
+						t.Fatalf("Fast path took %v %v %v", left, operatorType, right)
+					}
+					continue
+				}
+				if operatorType == token.SLASH && toFloat(right) == 0.0 {
+					if ok {
+						t.Fatalf("Fast path divided %v by zero", left)
+					}
+					continue
+				}
+				expected := handler(left, right, operator)
+				if !ok {
+					// Results beyond 2^53 skip it so they can be promoted
+					if result, isFloat := expected.(float64); !isFloat || math.Abs(result) < utils.MaxSafeInteger {
+						t.Fatalf("Fast path skipped %v %v %v", left, operatorType, right)
+					}
+					continue
+				}
+				if !reflect.DeepEqual(fast, expected) {
+					t.Fatalf("%v %v %v: fast path gave %v, general path gave %v", left, operatorType, right, fast, expected)
+				}
+			}
+		}
+	}
+
+	runOutputTests(t, outputOptions{}, []outputTest{
+		{"Loop crossing 2^53", `ধরি n = 9007199254740990; ফর (ধরি i = 0; i < 4; i = i + 1) { n = n + 1; } দেখাও n;`, "9007199254740994\n"},
+		{"Wide int64 operands", `ধরি wide = (1 << 60) | 1; দেখাও wide - (1 << 60); দেখাও wide == 1 << 60;`, "1\nfalse\n"},
+		{"Float loop", `ধরি s = 0; ফর (ধরি i = 0; i < 10; i = i + 1) { s = s + 0.1; } দেখাও s == 1;`, "false\n"},
+	})
+	runOutputTests(t, outputOptions{DecimalMode: true}, []outputTest{
+		{"Decimal loop", `ধরি s = 0; ফর (ধরি i = 0; i < 10; i = i + 1) { s = s + 0.1; } দেখাও s == 1;`, "true\n"},
+	})
+}
+
+// BenchmarkNumericLoop sums 1..10,000,000 in a ফর loop. The loop variable is
+// still looked up by name on every use: the loop scope is copied for each
+// iteration, so caching its environment slot has to wait for a resolver.
+func BenchmarkNumericLoop(b *testing.B) {
+	tokens, _ := lexer.NewScanner([]rune(`ধরি sum = 0; ফর (ধরি i = 1; i <= 10000000; i = i + 1) { sum = sum + i; } sum;`)).ScanTokens()
+	stmts, _ := parser.NewParser(tokens).Parse()
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		utils.HadRuntimeError = false
+		results := NewInterpreter().Interpret(stmts, false)
+		if sum := results[len(results)-1]; sum != 50000005000000.0 {
+			b.Fatalf("Expected 50000005000000, got %v", sum)
+		}
+	}
+}
+
+func TestRedeclaration(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{"Same scope is an error", `ধরি x = 1; ধরি x = 2;`, nil, "Cannot redeclare variable x."},
+		{"Same block scope is an error", `{ ধরি x = 1; ধরি x = 2; }`, nil, "Cannot redeclare variable x."},
+		{"Shadowing in a nested block", `ধরি x = 1; ধরি y = nil; { ধরি x = 2; y = x; } [x, y];`, []interface{}{1.0, 2.0}, ""},
+		{"Shadowing in a function", `ধরি x = 1; ফাংশন f() { ধরি x = 2; ফেরত x; } [f(), x];`, []interface{}{2.0, 1.0}, ""},
+	})
+}
+
+func TestReplRedeclaration(t *testing.T) {
+	utils.HadError = false
+	utils.HadRuntimeError = false
+
+	interpreter := NewInterpreter()
+	runLine := func(line string) []interface{} {
+		tokens, _ := lexer.NewScanner([]rune(line)).ScanTokens()
+		stmts, _ := parser.NewParser(tokens).Parse()
+		return interpreter.Interpret(stmts, true)
+	}
+
+	var results []interface{}
+	capturedErr := CaptureStderr(func() {
+		CaptureStdout(func() {
+			runLine(`ধরি x = 1;`)
+			runLine(`ধরি x = 2;`)
+			results = runLine(`x;`)
+		})
+	})
+	if utils.HadRuntimeError {
+		t.Fatalf("Unexpected error: %s", capturedErr)
+	}
+	if !reflect.DeepEqual(results, []interface{}{2.0}) {
+		t.Fatalf("Expected the REPL to rebind x to 2, got %v", results)
+	}
+
+	capturedErr = CaptureStderr(func() {
+		CaptureStdout(func() {
+			runLine(`{ ধরি y = 1; ধরি y = 2; }`)
+		})
+	})
+	if firstLine := strings.Split(capturedErr, "\n")[0]; firstLine != "Cannot redeclare variable y." {
+		t.Fatalf("Expected redeclaration in a block to fail in the REPL, got %q", firstLine)
+	}
+}
+
+func TestReplSuppressEcho(t *testing.T) {
+	utils.HadError = false
+	utils.HadRuntimeError = false
+
+	var stdout bytes.Buffer
+	interpreter := NewInterpreter()
+	interpreter.Stdout = &stdout
+	interpreter.SuppressEcho = true
+	runLine := func(line string) []interface{} {
+		tokens, _ := lexer.NewScanner([]rune(line)).ScanTokens()
+		stmts, _ := parser.NewParser(tokens).Parse()
+		return interpreter.Interpret(stmts, true)
+	}
+
+	var results []interface{}
+	capturedErr := CaptureStderr(func() {
+		runLine(`ধরি x = 1;`)
+		runLine(`ধরি x = 20;`)
+		results = runLine(`x + 1; "ক"; [x];`)
+	})
+	if utils.HadRuntimeError {
+		t.Fatalf("Unexpected error: %s", capturedErr)
+	}
+	if expected := []interface{}{21.0, "ক", []interface{}{20.0}}; !reflect.DeepEqual(results, expected) {
+		t.Fatalf("Expected results %v, got %v", expected, results)
+	}
+	if stdout.Len() != 0 {
+		t.Fatalf("Expected no echoed output, got %q", stdout.String())
+	}
+
+	// Explicit printing is unaffected
+	runLine(`দেখাও x;`)
+	if stdout.String() != "20\n" {
+		t.Fatalf("Expected দেখাও to still print, got %q", stdout.String())
+	}
+}
+
+func TestReplEchoesOnlyExpressions(t *testing.T) {
+	utils.HadError = false
+	utils.HadRuntimeError = false
+
+	var stdout bytes.Buffer
+	interpreter := NewInterpreter()
+	interpreter.Stdout = &stdout
+
+	tests := []struct {
+		line     string
+		expected string
+	}{
+		{`ধরি x = 5;`, ""},
+		{`5 + 5;`, "10\n"},
+		{`x;`, "5\n"},
+		{`ফাংশন f() { ফেরত 1; }`, ""},
+		{`ফাংশন g() { }`, ""},
+		{`g();`, ""},
+		{`f();`, "1\n"},
+		{`ধরি i = 0; যতক্ষণ (i < 3) { i = i + 1; }`, ""},
+		{`যদি (সত্য) { 7; }`, ""},
+		{`প্রত্যেক (v ইন [1, 2]) { v; }`, ""},
+		{`দেখাও "ক";`, "ক\n"},
+		{`1; 2;`, "1\n2\n"},
+	}
+	for _, tt := range tests {
+		stdout.Reset()
+		capturedErr := CaptureStderr(func() {
+			tokens, _ := lexer.NewScanner([]rune(tt.line)).ScanTokens()
+			stmts, _ := parser.NewParser(tokens).Parse()
+			interpreter.Interpret(stmts, true)
+		})
+		if utils.HadError || utils.HadRuntimeError {
+			t.Fatalf("%s: unexpected error: %s", tt.line, capturedErr)
+		}
+		if stdout.String() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.line, tt.expected, stdout.String())
+		}
+	}
+}
+
+func TestFunctionHoisting(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{"Call before declaration", `ধরি r = f(); ফাংশন f() { ফেরত 42; } r;`, 42.0, ""},
+		{
+			"Mutually recursive even and odd",
+			`ধরি r = [even(10), odd(7), even(3)];
+			ফাংশন even(n) { যদি (n == 0) ফেরত সত্য; ফেরত odd(n - 1); }
+			ফাংশন odd(n) { যদি (n == 0) ফেরত মিথ্যা; ফেরত even(n - 1); }
+			r;`,
+			[]interface{}{true, true, false}, "",
+		},
+		{"Hoisted inside a block", `ধরি r = nil; { r = g(); ফাংশন g() { ফেরত "ব্লক"; } } r;`, "ব্লক", ""},
+		{"Hoisted inside a function body", `ফাংশন outer() { ফেরত inner(); ফাংশন inner() { ফেরত 7; } } outer();`, 7.0, ""},
+		{"Block functions do not leak", `{ ফাংশন hidden() {} } hidden;`, nil, "Variable hidden is not defined."},
+		{"Hoisted function keeps its identity", `ধরি before = f; ফাংশন f() {} before == f;`, true, ""},
+	})
+}
+
+// failingReader fails every read, standing in for a broken stdin.
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("device not ready")
+}
+
+func TestInputReadErrors(t *testing.T) {
+	for _, input := range []string{`ইনপুট();`, `সংখ্যা_ইনপুট();`} {
+		utils.HadError = false
+		utils.HadRuntimeError = false
+
+		i := NewInterpreter()
+		i.Stdin = failingReader{}
+		capturedErr := CaptureStderr(func() {
+			tokens, _ := lexer.NewScanner([]rune(input)).ScanTokens()
+			stmts, _ := parser.NewParser(tokens).Parse()
+			i.Interpret(stmts, false)
+		})
+		if expected := "failed to read input: device not ready"; strings.Split(capturedErr, "\n")[0] != expected {
+			t.Fatalf("Expected error %q for %s, got %q", expected, input, capturedErr)
+		}
+	}
+}
+
+func TestInputFunctions(t *testing.T) {
+	tests := []struct {
+		name     string
+		stdin    string
+		input    string
+		expected interface{}
+		stdout   string
+		errorMsg string
+	}{
+		{"Input trims the line", "  বর্ণ  \nnext\n", `ইনপুট("নাম: ");`, "বর্ণ", "নাম: ", ""},
+		{"Input without trailing newline", "last", `ইনপুট();`, "last", "", ""},
+		{"Input at EOF", "", `ইনপুট();`, nil, "", ""},
+		{"Input after the last line", "only\n", `ইনপুট(); ইনপুট();`, nil, "", ""},
+		{"Number input at EOF", "", `সংখ্যা_ইনপুট();`, nil, "", ""},
+		{
+			"Reading until EOF",
+			"a\nb\nc",
+			`ধরি lines = []; ধরি line = ইনপুট(); যতক্ষণ (line != nil) { lines = এড(lines, line); line = ইনপুট(); } lines;`,
+			[]interface{}{"a", "b", "c"}, "", "",
+		},
+		{"Number input", "42.5\n", `সংখ্যা_ইনপুট("? ");`, 42.5, "? ", ""},
+		{"Number input with Bangla digits", "১২\n", `সংখ্যা_ইনপুট();`, 12.0, "", ""},
+		{"Invalid number input", "abc\n", `সংখ্যা_ইনপুট();`, nil, "", `expected a number, got string "abc"`},
+		{"Read all", "line 1\nline 2\n", `সব_ইনপুট();`, "line 1\nline 2\n", "", ""},
+		{"Read all after a line", "first\nrest 1\nrest 2", `ধরি a = ইনপুট(); সব_ইনপুট();`, "rest 1\nrest 2", "", ""},
+		{"Read all on empty stdin", "", `সব_ইনপুট();`, "", "", ""},
+		{"Lines share one buffer", "1\n2\n", `সংখ্যা_ইনপুট() + সংখ্যা_ইনপুট();`, 3.0, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			utils.HadError = false
+			utils.HadRuntimeError = false
+
+			var stdout bytes.Buffer
+			i := NewInterpreter()
+			i.Stdin = strings.NewReader(tt.stdin)
+			i.Stdout = &stdout
+
+			var output interface{}
+			capturedErr := CaptureStderr(func() {
+				tokens, _ := lexer.NewScanner([]rune(tt.input)).ScanTokens()
+				stmts, _ := parser.NewParser(tokens).Parse()
+				if results := i.Interpret(stmts, false); len(results) > 0 {
+					output = results[len(results)-1]
+				}
+			})
+
+			if tt.errorMsg != "" {
+				if firstLine := strings.Split(capturedErr, "\n")[0]; firstLine != tt.errorMsg {
+					t.Fatalf("Expected error %q, got %q", tt.errorMsg, firstLine)
+				}
+				return
+			}
+			if utils.HadError || utils.HadRuntimeError {
+				t.Fatalf("Unexpected error: %s", capturedErr)
+			}
+			if !reflect.DeepEqual(output, tt.expected) {
+				t.Fatalf("Expected %q, got %q", tt.expected, output)
+			}
+			if stdout.String() != tt.stdout {
+				t.Fatalf("Expected prompt %q, got %q", tt.stdout, stdout.String())
+			}
+		})
+	}
+}
+
+func TestNumberFormatting(t *testing.T) {
+	runOutputTests(t, outputOptions{}, []outputTest{
+		{"Sum of tenths", `দেখাও 0.1 + 0.2;`, "0.3\n"},
+		{"Repeating fraction", `দেখাও 1 / 3;`, "0.333333333333333\n"},
+		{"Subtraction noise", `দেখাও 1.1 - 1;`, "0.1\n"},
+		{"Whole number", `দেখাও 100;`, "100\n"},
+		{"Large number", `দেখাও 1000000;`, "1000000\n"},
+		{"Very large number", `দেখাও 1000000000.5 * 1000000000000;`, "1.0000000005e+21\n"},
+		{"Very large integer", `দেখাও 1000000000 * 1000000000 * 1000;`, "1000000000000000000000\n"},
+		{"Numbers inside containers", `দেখাও [0.1 + 0.2, {a: 0.1 * 3}];`, "[0.3, {a: 0.3}]\n"},
+		{"Integers are unaffected", `দেখাও 1 << 60;`, "1152921504606846976\n"},
+		{"Sixteen-digit whole number", `দেখাও 6402373705728000;`, "6402373705728000\n"},
+		{"Largest exact whole number", `দেখাও 9007199254740992.0;`, "9007199254740992\n"},
+		{"Negative sixteen-digit whole number", `দেখাও -6402373705728000;`, "-6402373705728000\n"},
+		{"Round to decimal places", `দেখাও গোল_করে(3.14159, 2);`, "3.14\n"},
+		{"Round to whole number", `দেখাও গোল_করে(2.5, 0);`, "3\n"},
+		{"Round keeps trailing zeros out", `দেখাও গোল_করে(1.2, 3);`, "1.2\n"},
+	})
+
+	runOutputTests(t, outputOptions{Precision: 3}, []outputTest{
+		{"Whole numbers ignore custom precision", `দেখাও 123456;`, "123456\n"},
+		{"Custom precision", `দেখাও 3.14159;`, "3.14\n"},
+	})
+
+	runOutputTests(t, outputOptions{Precision: -1}, []outputTest{
+		{"Exact precision", `দেখাও 0.1 + 0.2;`, "0.30000000000000004\n"},
+	})
+}
+
+func TestRoundTo(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{"Round down", `গোল_করে(1.234, 2);`, 1.23, ""},
+		{"Round up", `গোল_করে(1.236, 2);`, 1.24, ""},
+		{"Negative number", `গোল_করে(-1.236, 1);`, -1.2, ""},
+		{"Many digits", `গোল_করে(0.5, 400);`, 0.5, ""},
+		{"Fractional digits", `গোল_করে(1, 0.5);`, nil, "গোল_করে-এর দ্বিতীয় আর্গুমেন্ট পূর্ণসংখ্যা হতে হবে, পেয়েছি 0.5"},
+		{"Negative digits", `গোল_করে(1, -1);`, nil, "গোল_করে-এর দ্বিতীয় আর্গুমেন্ট অঋণাত্মক পূর্ণসংখ্যা হতে হবে, পেয়েছি -1"},
+		{"Non-number", `গোল_করে(nil, 1);`, nil, "গোল_করে শুধু সংখ্যায় কাজ করে, পেয়েছি nil"},
+	})
+}
+
+// য় can be typed as one code point (U+09DF) or as য plus a nukta (U+09AF
+// U+09BC). Names mean the same thing either way.
+func TestNameSpellings(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{"Native spelled with a nukta", "সংজ্ঞা\u09AF\u09BCিত(\"লেন\");", true, ""},
+		{"Native spelled precomposed", "সংজ্ঞা\u09DFিত(\"লেন\");", true, ""},
+		{"Native name string with a nukta", "সংজ্ঞা\u09DFিত(\"সম\u09AF\u09BC_মাপো\");", true, ""},
+		{"Variable declared one way and read the other", "ধরি সম\u09DF = 1; সম\u09AF\u09BC;", 1.0, ""},
+		{"Keyword spelled with a nukta", "ধরি r = 0; যদি (মিথ্যা) { r = 1; } নাহ\u09AF\u09BC { r = 2; } r;", 2.0, ""},
+		{"Method spelled with a nukta", "\"কখ\".দি\u09AF\u09BCে_শুরু(\"ক\");", true, ""},
+		{"Property key and string key", "ধরি o = {সম\u09DF: 1}; [o[\"সম\u09AF\u09BC\"], o.সম\u09AF\u09BC];", []interface{}{1.0, 1.0}, ""},
+		{"String key and property", "ধরি o = {\"সম\u09AF\u09BC\": 1}; o.সম\u09DF;", 1.0, ""},
+		{"Reserved either way", "ধরি সংজ্ঞা\u09AF\u09BCিত = 1;", nil, "[line 1] Error at 'সংজ্ঞা\u09AF\u09BCিত': 'সংজ্ঞা\u09AF\u09BCিত' is a reserved identifier and cannot be used as a variable name."},
+	})
+}
+
+func TestScopeNatives(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{
+			"Read a global shadowed by a local",
+			`ধরি x = 1; ফাংশন f() { ধরি x = 2; ফেরত [x, গ্লোবাল_পাও("x")]; } f();`,
+			[]interface{}{2.0, 1.0}, "",
+		},
+		{
+			"Read a global shadowed in a block",
+			`ধরি x = 1; { ধরি x = 2; ধরি r = গ্লোবাল_পাও("x"); } x;`,
+			1.0, "",
+		},
+		{
+			"Assign a global shadowed by a local",
+			`ধরি x = 1; ফাংশন f() { ধরি x = 2; গ্লোবাল_সেট("x", 3); ফেরত x; } [f(), x];`,
+			[]interface{}{2.0, 3.0}, "",
+		},
+		{"Read an undefined global", `গ্লোবাল_পাও("x");`, nil, "undefined global variable 'x'"},
+		{"Assign an undefined global", `গ্লোবাল_সেট("x", 1);`, nil, "undefined global variable 'x'"},
+		{"Natives are not reassignable", `গ্লোবাল_সেট("লেন", 1);`, nil, "undefined global variable 'লেন'"},
+		{"Undefined variable", `সংজ্ঞায়িত("x");`, false, ""},
+		{"Uninitialized variable is defined", `ধরি x; সংজ্ঞায়িত("x");`, true, ""},
+		{"Natives are defined", `সংজ্ঞায়িত("লেন");`, true, ""},
+		{
+			"Definedness follows the calling scope",
+			`ফাংশন f() { ধরি y = 1; ফেরত সংজ্ঞায়িত("y"); } [f(), সংজ্ঞায়িত("y")];`,
+			[]interface{}{true, false}, "",
+		},
+		{"Name must be a string", `সংজ্ঞায়িত(1);`, nil, "সংজ্ঞায়িত শুধু স্ট্রিংয়ে কাজ করে, পেয়েছি সংখ্যা"},
+	})
+}
+
+func TestClosures(t *testing.T) {
+	makeCounter := `ফাংশন makeCounter() {
+		ধরি count = 0;
+		ফাংশন inc() {
+			count = count + 1;
+			ফেরত count;
+		}
+		ফেরত inc;
+	}
+	`
+	runSourceTests(t, []sourceTest{
+		{
+			"Counter accumulates across calls",
+			makeCounter + `ধরি c = makeCounter(); [c(), c(), c()];`,
+			[]interface{}{1.0, 2.0, 3.0}, "",
+		},
+		{
+			"Counters are independent",
+			makeCounter + `ধরি a = makeCounter(); ধরি b = makeCounter(); a(); a(); [a(), b()];`,
+			[]interface{}{3.0, 1.0}, "",
+		},
+		{
+			"Sibling closures share a variable",
+			`ফাংশন make() {
+				ধরি n = 0;
+				ফাংশন inc() { n = n + 1; }
+				ফাংশন get() { ফেরত n; }
+				ফেরত [inc, get];
+			}
+			ধরি fs = make();
+			ধরি inc = fs[0];
+			ধরি get = fs[1];
+			inc(); inc();
+			get();`,
+			2.0, "",
+		},
+		{
+			"Closure sees later outer assignments",
+			`ধরি x = 1; ফাংশন f() { ফেরত x; } x = 2; f();`,
+			2.0, "",
+		},
+		{
+			"For loop variable is rebound per iteration",
+			`ধরি funcs = [];
+			ফর (ধরি i = 0; i < 3; i = i + 1) {
+				ফাংশন get() { ফেরত i; }
+				funcs = এড(funcs, get);
+			}
+			[funcs[0](), funcs[1](), funcs[2]()];`,
+			[]interface{}{0.0, 1.0, 2.0}, "",
+		},
+		{
+			"Changes in the body carry into the next iteration",
+			`ধরি seen = [];
+			ফর (ধরি i = 0; i < 6; i = i + 1) {
+				seen = এড(seen, i);
+				i = i + 1;
+			}
+			seen;`,
+			[]interface{}{0.0, 2.0, 4.0}, "",
+		},
+		{
+			"Closure sees later changes within its own iteration",
+			`ধরি f = nil;
+			ফর (ধরি i = 0; i < 1; i = i + 1) {
+				ফাংশন get() { ফেরত i; }
+				f = get;
+				i = 10;
+			}
+			f();`,
+			10.0, "",
+		},
+	})
+}
+
+func TestSplit(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{"Split on delimiter", `ভাঙো("a,b,c", ",");`, []interface{}{"a", "b", "c"}, ""},
+		{"Split keeps empty pieces", `ভাঙো("a,,b", ",");`, []interface{}{"a", "", "b"}, ""},
+		{"Split Bangla text", `ভাঙো("আম জাম কাঁঠাল", " ");`, []interface{}{"আম", "জাম", "কাঁঠাল"}, ""},
+		{"Empty delimiter splits characters", `ভাঙো("কখ", "");`, []interface{}{"ক", "খ"}, ""},
+		{"Limit keeps the rest in the last piece", `ভাঙো("a,b,c,d", ",", 2);`, []interface{}{"a", "b,c,d"}, ""},
+		{"Limit larger than piece count", `ভাঙো("a,b", ",", 5);`, []interface{}{"a", "b"}, ""},
+		{"Limit of one", `ভাঙো("a,b", ",", 1);`, []interface{}{"a,b"}, ""},
+		{"Zero limit", `ভাঙো("a,b", ",", 0);`, nil, "ভাঙো-এর তৃতীয় আর্গুমেন্ট ধনাত্মক পূর্ণসংখ্যা হতে হবে, পেয়েছি 0"},
+		{"Split as a method", `"x-y".ভাঙো("-");`, []interface{}{"x", "y"}, ""},
+		{"Split non-string", `ভাঙো(1, ",");`, nil, "ভাঙো শুধু স্ট্রিংয়ে কাজ করে, পেয়েছি সংখ্যা"},
+		{"Regex split on whitespace runs", "রেজেক্স_ভাঙো(\"আম  জাম\t \tকাঁঠাল\", \"\\s+\");", []interface{}{"আম", "জাম", "কাঁঠাল"}, ""},
+		{"Regex split with character class", `রেজেক্স_ভাঙো("a1b22c", "[0-9]+");`, []interface{}{"a", "b", "c"}, ""},
+		{"Regex split without match", `রেজেক্স_ভাঙো("abc", ",");`, []interface{}{"abc"}, ""},
+		{"Invalid regex", `রেজেক্স_ভাঙো("abc", "(");`, nil, "regex_split function got an invalid pattern: error parsing regexp: missing closing ): `(`"},
+	})
+}
+
+func TestRegexMatching(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{"Matches", `মেলে("abc123", "[0-9]+");`, true, ""},
+		{"Does not match", `মেলে("abc", "^[0-9]+$");`, false, ""},
+		{"Matches Bangla text", `মেলে("আমার সোনার বাংলা", "^আমার .+ বাংলা$");`, true, ""},
+		{"Bangla character class", `মেলে("কখগ", "^[ক-ঘ]{3}$");`, true, ""},
+		{"Find first match", `খুঁজো("a1 b22 c333", "[0-9]+");`, "1", ""},
+		{"Find Bangla word", `খুঁজো("দাম: ১২০ টাকা", "[০-৯]+");`, "১২০", ""},
+		{"Find without match", `খুঁজো("abc", "[0-9]");`, nil, ""},
+		{"Find all matches", `সব_খুঁজো("a1 b22 c333", "[0-9]+");`, []interface{}{"1", "22", "333"}, ""},
+		{"Find all without match", `সব_খুঁজো("abc", "[0-9]");`, []interface{}{}, ""},
+		{"Find as a method", `"x=1, y=2".সব_খুঁজো("[a-z]=");`, []interface{}{"x=", "y="}, ""},
+		{"Invalid pattern", `মেলে("abc", "[a-");`, nil, "matches function got an invalid pattern: error parsing regexp: missing closing ]: `[a-`"},
+		{"Non-string subject", `খুঁজো(1, "1");`, nil, "খুঁজো শুধু স্ট্রিংয়ে কাজ করে, পেয়েছি সংখ্যা"},
+	})
+}
+
+func TestRegexCache(t *testing.T) {
+	i := NewInterpreter()
+	first, err := i.compilePattern("[0-9]+", "matches", "মেলে")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	second, _ := i.compilePattern("[0-9]+", "find", "খুঁজো")
+	if first != second {
+		t.Fatalf("Expected the compiled pattern to be reused")
+	}
+	if _, err := i.compilePattern("(", "matches", "মেলে"); err == nil {
+		t.Fatalf("Expected an invalid pattern to fail")
+	}
+	if _, cached := i.regexCache["("]; cached {
+		t.Fatalf("Expected an invalid pattern not to be cached")
+	}
+}
+
+func TestMultipleDeclarationWithLiterals(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{
+			"Object spanning lines then scalar",
+			"ধরি a = {x: 1,\n y: 2}, b = 5;\n[a.x + a.y, b];",
+			[]interface{}{3.0, 5.0}, "",
+		},
+		{
+			"Scalar then array spanning lines",
+			"ধরি a = 1, b = [\n2,\n3\n];\n[a, b[1]];",
+			[]interface{}{1.0, 3.0}, "",
+		},
+	})
+}
+
+func TestTryCatchFinally(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{
+			"Finally runs on normal completion",
+			`ধরি n = 0; চেষ্টা { n = 1; } অবশেষে { n = n + 10; } n;`,
+			11.0, "",
+		},
+		{
+			"Return in try runs finally",
+			`ধরি ran = 0;
+			ফাংশন f() { চেষ্টা { ফেরত 1; } অবশেষে { ran = ran + 1; } ফেরত 2; }
+			[f(), ran];`,
+			[]interface{}{1.0, 1.0}, "",
+		},
+		{
+			"Return in finally replaces return in try",
+			`ফাংশন f() { চেষ্টা { ফেরত 1; } অবশেষে { ফেরত 2; } } f();`,
+			2.0, "",
+		},
+		{
+			"Throw in try is caught and finally runs",
+			`ধরি steps = 0; ধরি caught = nil;
+			চেষ্টা { নিক্ষেপ 5; steps = 100; } ধরো (e) { caught = e; } অবশেষে { steps = steps + 1; }
+			[caught, steps];`,
+			[]interface{}{5.0, 1.0}, "",
+		},
+		{
+			"Throw in finally overrides the pending throw",
+			`ধরি r = nil;
+			চেষ্টা { চেষ্টা { নিক্ষেপ 1; } অবশেষে { নিক্ষেপ 2; } } ধরো (e) { r = e; }
+			r;`,
+			2.0, "",
+		},
+		{
+			"Throw in finally overrides a return",
+			`ধরি r = nil;
+			ফাংশন f() { চেষ্টা { ফেরত 1; } অবশেষে { নিক্ষেপ 2; } }
+			চেষ্টা { f(); } ধরো (e) { r = e; }
+			r;`,
+			2.0, "",
+		},
+		{
+			"Throw in catch still runs finally",
+			`ধরি ran = 0; ধরি r = nil;
+			চেষ্টা { চেষ্টা { নিক্ষেপ 1; } ধরো (e) { নিক্ষেপ e + 1; } অবশেষে { ran = 1; } } ধরো (e) { r = e; }
+			[r, ran];`,
+			[]interface{}{2.0, 1.0}, "",
+		},
+		{
+			"Runtime error in catch still runs finally",
+			`ধরি ran = 0; ধরি r = nil;
+			চেষ্টা { চেষ্টা { নিক্ষেপ 1; } ধরো (e) { ধরি x = 1 / 0; } অবশেষে { ran = 1; } } ধরো (e) { r = e.message; }
+			[r, ran];`,
+			[]interface{}{"Division by zero.", 1.0}, "",
+		},
+		{
+			"Break in try runs finally",
+			`ধরি n = 0; যতক্ষণ (সত্য) { চেষ্টা { থামো; } অবশেষে { n = n + 1; } } n;`,
+			1.0, "",
+		},
+		{
+			"Continue in try runs finally",
+			`ধরি n = 0; ফর (ধরি i = 0; i < 3; i = i + 1) { চেষ্টা { চালিয়ে_যাও; } অবশেষে { n = n + 1; } } n;`,
+			3.0, "",
+		},
+		{
+			"Throw unwinds through functions and loops",
+			`ফাংশন f() { যতক্ষণ (সত্য) { নিক্ষেপ "ভুল"; } }
+			ধরি r = nil;
+			চেষ্টা { f(); } ধরো (e) { r = e; }
+			r;`,
+			"ভুল", "",
+		},
+		{
+			"Catch variable is scoped to the catch body",
+			`চেষ্টা { নিক্ষেপ 1; } ধরো (e) { } সংজ্ঞায়িত("e");`,
+			false, "",
+		},
+		{"Uncaught throw", `নিক্ষেপ "oops";`, nil, "Uncaught exception: oops"},
+		{"Uncaught throw from a function", `ফাংশন f() { নিক্ষেপ [1]; } f();`, nil, "Uncaught exception: [1]"},
+		{"Finally without catch rethrows", `চেষ্টা { নিক্ষেপ 3; } অবশেষে { }`, nil, "Uncaught exception: 3"},
+	})
+}
+
+func TestUncaughtErrorInCatchRunsFinally(t *testing.T) {
+	var capturedErr string
+	output := CaptureStdout(func() {
+		_, capturedErr = runSource(t, `চেষ্টা { ১/০; } ধরো (e) { ১/০; } অবশেষে { দেখাও "x"; } দেখাও "after";`)
+	})
+	if output != "x\n" {
+		t.Fatalf("Expected %q, got %q", "x\n", output)
+	}
+	if capturedErr != "Division by zero.\n[line 1]\n" {
+		t.Fatalf("Expected the catch body's error, got %q", capturedErr)
+	}
+}
+
+func TestErrorObjects(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{"Error with message", `এরর("bad");`, map[string]interface{}{"type": "Error", "message": "bad"}, ""},
+		{"Error with type and message", `এরর("ValueError", "bad");`, map[string]interface{}{"type": "ValueError", "message": "bad"}, ""},
+		{"Error needs strings", `এরর(1);`, nil, "error function expects string arguments"},
+		{
+			"Catch branches on type",
+			`ধরি r = nil;
+			চেষ্টা { নিক্ষেপ এরর("ValueError", "bad"); } ধরো (e) { যদি (e.type == "ValueError") r = e.message; }
+			r;`,
+			"bad", "",
+		},
+		{
+			"Division by zero is catchable",
+			`ধরি r = nil; চেষ্টা { ধরি x = 1 / 0; } ধরো (e) { r = [e.type, e.message]; } r;`,
+			[]interface{}{"RuntimeError", "Division by zero."}, "",
+		},
+		{
+			"Index out of bounds inside a function is catchable",
+			`ফাংশন at(a, i) { ফেরত a[i]; }
+			ধরি r = nil; চেষ্টা { at([1], 5); } ধরো (e) { r = e.message; } r;`,
+			"Array index out of bounds.", "",
+		},
+		{
+			"Execution continues after a caught runtime error",
+			`ধরি n = 0; চেষ্টা { n = 1 / 0; } ধরো (e) { } n = n + 1; n;`,
+			1.0, "",
+		},
+		{
+			"Finally runs after a runtime error",
+			`ধরি ran = 0; চেষ্টা { চেষ্টা { ধরি x = [][0]; } অবশেষে { ran = 1; } } ধরো (e) { } ran;`,
+			1.0, "",
+		},
+		{"Runtime error outside a try still stops", `ধরি x = 1 / 0;`, nil, "Division by zero."},
+		{"Runtime error in catch is not caught by itself", `চেষ্টা { নিক্ষেপ 1; } ধরো (e) { ধরি x = 1 / 0; }`, nil, "Division by zero."},
+		{"Uncaught error object", `নিক্ষেপ এরর("ValueError", "bad");`, nil, "Uncaught exception: ValueError: bad"},
+		{"Uncaught runtime error with finally", `চেষ্টা { ধরি x = 1 / 0; } অবশেষে { }`, nil, "Uncaught exception: RuntimeError: Division by zero."},
+	})
+}
+
+func TestHigherOrderNatives(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{"Map", `ফাংশন double(x) { ফেরত x * 2; } ম্যাপ([1, 2, 3], double);`, []interface{}{2.0, 4.0, 6.0}, ""},
+		{"Map with index", `ফাংশন f(x, i) { ফেরত x + i; } ম্যাপ([10, 20, 30], f);`, []interface{}{10.0, 21.0, 32.0}, ""},
+		{"Map with a native", `ম্যাপ([[1], [1, 2]], লেন);`, []interface{}{1, 2}, ""},
+		{"Map as a method", `ফাংশন sq(x) { ফেরত x * x; } [2, 3].ম্যাপ(sq);`, []interface{}{4.0, 9.0}, ""},
+		{"Filter", `ফাংশন even(x) { ফেরত x % 2 == 0; } ফিল্টার([1, 2, 3, 4], even);`, []interface{}{2.0, 4.0}, ""},
+		{"Filter with index", `ফাংশন odd(x, i) { ফেরত i % 2 == 1; } ফিল্টার(["a", "b", "c", "d"], odd);`, []interface{}{"b", "d"}, ""},
+		{
+			"For each with index",
+			`ধরি sum = 0; ফাংশন add(x, i) { sum = sum + x * i; } প্রত্যেক_উপাদান([5, 6, 7], add); sum;`,
+			20.0, "",
+		},
+		{"Find element", `ফাংশন big(x) { ফেরত x > 2; } খুঁজে_পাও([1, 3, 5], big);`, 3.0, ""},
+		{"Find element without match", `ফাংশন big(x) { ফেরত x > 9; } খুঁজে_পাও([1, 3, 5], big);`, nil, ""},
+		{"Every", `ফাংশন pos(x) { ফেরত x > 0; } [প্রতিটি([1, 2], pos), প্রতিটি([1, -2], pos), প্রতিটি([], pos)];`, []interface{}{true, false, true}, ""},
+		{"Some", `ফাংশন neg(x) { ফেরত x < 0; } [কিছু([1, -2], neg), কিছু([1, 2], neg), কিছু([], neg)];`, []interface{}{true, false, false}, ""},
+		{
+			"Every short-circuits",
+			`ধরি calls = 0; ফাংশন small(x) { calls = calls + 1; ফেরত x < 2; } প্রতিটি([1, 5, 1, 1], small); calls;`,
+			2.0, "",
+		},
+		{
+			"Some short-circuits",
+			`ধরি calls = 0; ফাংশন big(x) { calls = calls + 1; ফেরত x > 2; } কিছু([1, 5, 9, 9], big); calls;`,
+			2.0, "",
+		},
+		{
+			"Find element short-circuits",
+			`ধরি calls = 0; ফাংশন big(x) { calls = calls + 1; ফেরত x > 2; } খুঁজে_পাও([5, 9], big); calls;`,
+			1.0, "",
+		},
+		{"Callback with too many parameters", `ফাংশন f(a, b, c) { } ম্যাপ([1], f);`, nil, "Expected 3 arguments but got 1."},
+		{"Non-array", `ফাংশন f(x) { } ম্যাপ(1, f);`, nil, "ম্যাপ শুধু অ্যারেতে কাজ করে, পেয়েছি সংখ্যা"},
+		{"Non-callable", `ফিল্টার([1], 1);`, nil, "ফিল্টার-এর দ্বিতীয় আর্গুমেন্ট ফাংশন হতে হবে, পেয়েছি সংখ্যা"},
+		{
+			"Throw from a callback",
+			`ফাংশন f(x) { নিক্ষেপ x; } ধরি r = nil; চেষ্টা { ম্যাপ([7], f); } ধরো (e) { r = e; } r;`,
+			7.0, "",
+		},
+		{"Runtime error in a callback", `ফাংশন f(x) { ফেরত x / 0; } ম্যাপ([1, 2], f);`, nil, "Division by zero."},
+	})
+}
+
+func bigInt(digits string) *big.Int {
+	n, _ := new(big.Int).SetString(digits, 10)
+	return n
+}
+
+func TestBigIntegers(t *testing.T) {
+	factorial := `ফাংশন f(n) { যদি (n <= 1) { ফেরত 1; } ফেরত n * f(n - 1); } `
+	runSourceTests(t, []sourceTest{
+		{"Factorial of 50", factorial + `f(50);`, bigInt("30414093201713378043612608166064768844377641568960512000000000000"), ""},
+		{"Factorial that fits a float", factorial + `f(18);`, 6402373705728000.0, ""},
+		{"First factorial past the float range", factorial + `f(19);`, bigInt("121645100408832000"), ""},
+		{"Exact division", factorial + `f(50) / f(48);`, 2450.0, ""},
+		{"Uneven division falls back to float", factorial + `f(20) / 23;`, 2432902008176640000.0 / 23, ""},
+		{"Modulo", factorial + `f(30) % 1000007;`, 790627.0, ""},
+		{"Comparison", factorial + `[f(25) > f(24), f(25) == f(25), f(25) != f(25) + 1];`, []interface{}{true, true, true}, ""},
+		{"Negation", factorial + `-f(20);`, bigInt("-2432902008176640000"), ""},
+		{"Shrinks back to a float", factorial + `f(22) - f(22) + 1;`, 1.0, ""},
+		{"Mixed with a fraction", factorial + `f(20) * 0.5;`, 1216451004088320000.0, ""},
+		{"Large literal", `123456789012345678901234567890 + 1;`, bigInt("123456789012345678901234567891"), ""},
+		{"Power", `2 ** 100;`, bigInt("1267650600228229401496703205376"), ""},
+		{"Left shift", `1 << 70;`, bigInt("1180591620717411303424"), ""},
+		{"Bitwise on a big integer", `(1 << 70) >> 68;`, int64(4), ""},
+		{"Concatenation", factorial + `"n = " + f(21);`, "n = 51090942171709440000", ""},
+		{"Small arithmetic stays float", `2 ** 10 + 3 * 4;`, 1036.0, ""},
+
+		// 2^53 - 1 is the largest integer below which every float is exact;
+		// 2^53 + 1 rounds to 2^53 as a float, so reaching 2^53 needs checking
+		{"Sum below 2^53", `9007199254740990 + 1;`, 9007199254740991.0, ""},
+		{"Sum at 2^53", `9007199254740991 + 1;`, bigInt("9007199254740992"), ""},
+		{"Literal at 2^53 matches the sum", `9007199254740992;`, bigInt("9007199254740992"), ""},
+		{"Sum past 2^53", `9007199254740991 + 2;`, bigInt("9007199254740993"), ""},
+		{"Difference below -2^53", `-9007199254740990 - 1;`, -9007199254740991.0, ""},
+		{"Difference at -2^53", `-9007199254740991 - 1;`, bigInt("-9007199254740992"), ""},
+		{"Difference past -2^53", `-9007199254740991 - 2;`, bigInt("-9007199254740993"), ""},
+		{"Difference from past 2^53", `9007199254740993 - 1;`, bigInt("9007199254740992"), ""},
+		{"Product below 2^53", `3002399751580330 * 3 + 1;`, 9007199254740991.0, ""},
+		{"Product at 2^53", `4503599627370496 * 2;`, bigInt("9007199254740992"), ""},
+		{"Product past 2^53", `4503599627370496 * 2 + 1;`, bigInt("9007199254740993"), ""},
+	})
+}
+
+func TestDecimalMode(t *testing.T) {
+	runOutputTests(t, outputOptions{DecimalMode: true}, []outputTest{
+		{"Sum of tenths is exact", `দেখাও 0.1 + 0.2 == 0.3, 0.1 + 0.2;`, "true 0.3\n"},
+		{"Subtraction", `দেখাও 1.1 - 1, -0.1 - 0.2;`, "0.1 -0.3\n"},
+		{"Multiplication", `দেখাও 0.1 * 3 == 0.3, 1.1 * 1.1;`, "true 1.21\n"},
+		{"Whole results are integers", `ধরি a = [1, 2, 3, 4]; দেখাও a[1.5 * 2], 0.5 + 0.5;`, "4 1\n"},
+		{"Terminating division is exact", `দেখাও 1 / 4, 10 / 4, -0.25 / 2;`, "0.25 2.5 -0.125\n"},
+		{"Division rounds to 20 places", `দেখাও 1 / 3, 2 / 3;`, "0.33333333333333333333 0.66666666666666666667\n"},
+		{"Division rounds half to even", `দেখাও 0.000000000000000000025 / 1, 0.000000000000000000035 / 1;`, "0.00000000000000000002 0.00000000000000000004\n"},
+		{"Rounded quotients do not round-trip", `দেখাও 1 / 3 * 3;`, "0.99999999999999999999\n"},
+		{"Modulo", `দেখাও 5.5 % 2, -5.5 % 2;`, "1.5 -1.5\n"},
+		{"Power", `দেখাও 1.1 ** 2, 2 ** -2;`, "1.21 0.25\n"},
+		{"Comparison", `দেখাও 0.3 > 0.1 + 0.1, 0.3 <= 0.1 + 0.2;`, "true true\n"},
+		{"Concatenation", `দেখাও "মোট: " + (0.1 + 0.2);`, "মোট: 0.3\n"},
+		{"Inside containers", `দেখাও [0.1 + 0.2], {a: 0.7 * 3};`, "[0.3] {a: 2.1}\n"},
+	})
+
+	runOutputTests(t, outputOptions{}, []outputTest{
+		{"Float mode still drifts", `দেখাও 0.1 + 0.2 == 0.3;`, "false\n"},
+	})
+}
+
+func TestTailCalls(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{
+			"Tail-recursive sum of a million numbers",
+			`ফাংশন sum(n, acc) { যদি (n == 0) { ফেরত acc; } ফেরত sum(n - 1, acc + n); } sum(1000000, 0);`,
+			500000500000.0, "",
+		},
+		{
+			"Tail call from inside a loop",
+			`ফাংশন count(n) { যতক্ষণ (সত্য) { যদি (n <= 0) { ফেরত "done"; } ফেরত count(n - 1); } } count(200000);`,
+			"done", "",
+		},
+		{
+			"Each iteration gets its own scope",
+			`ফাংশন collect(n, fns) {
+				যদি (n == 0) { ফেরত fns; }
+				ফাংশন get() { ফেরত n; }
+				ফেরত collect(n - 1, এড(fns, get));
+			}
+			ধরি fns = collect(3, []);
+			[fns[0](), fns[2]()];`,
+			[]interface{}{3.0, 1.0}, "",
+		},
+		{
+			"Non-tail recursion still works",
+			`ফাংশন fact(n) { যদি (n <= 1) { ফেরত 1; } ফেরত n * fact(n - 1); } fact(10);`,
+			3628800.0, "",
+		},
+		{
+			"Try keeps catching errors from the recursive call",
+			`ফাংশন f(n) { চেষ্টা { যদি (n == 0) { নিক্ষেপ "bottom"; } ফেরত f(n - 1); } ধরো (e) { ফেরত "caught at " + n; } } f(3);`,
+			"caught at 0", "",
+		},
+		{
+			"Shadowed name is not a self-call",
+			`ফাংশন f(n) { ফাংশন f(x) { ফেরত x * 10; } ফেরত f(n); } f(4);`,
+			40.0, "",
+		},
+		{"Wrong argument count", `ফাংশন f(n) { ফেরত f(n, 1); } f(1);`, nil, "Expected 1 argument but got 2."},
+	})
+}
+
+func TestNativeErrorsAreReportedAtTheCall(t *testing.T) {
+	_, capturedErr := runSource(t, "ধরি x = 1;\n\nএড(x, 5);")
+	if expected := "এড শুধু অ্যারেতে কাজ করে, পেয়েছি সংখ্যা\n[line 3]\n"; capturedErr != expected {
+		t.Fatalf("Expected error %q, got %q", expected, capturedErr)
+	}
+
+	// A native error raised inside a callback keeps the message of the inner call
+	_, capturedErr = runSource(t, "ফাংশন f(x) {\n  ফেরত এড(x, 1);\n}\nম্যাপ([1], f);")
+	if expected := "এড শুধু অ্যারেতে কাজ করে, পেয়েছি সংখ্যা\n[line 2]\nat f (line 2)\n"; capturedErr != expected {
+		t.Fatalf("Expected error %q, got %q", expected, capturedErr)
+	}
+}
+
+func TestCallErrorsReportTheCallLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Arguments span several lines", "ধরি x = 1;\nএড(x,\n  5\n);", "এড শুধু অ্যারেতে কাজ করে, পেয়েছি সংখ্যা\n[line 2]\n"},
+		{"Wrong argument count", "ফাংশন f(a) { ফেরত a; }\n\nf(1,\n2);", "Expected 1 argument but got 2.\n[line 3]\n"},
+		{"Calling a non-function", "ধরি x = 1;\nx(\n);", "শুধু ফাংশন কল করা যায়, পেয়েছি সংখ্যা\n[line 2]\n"},
+		{"Error inside the called function keeps its own line", "ফাংশন f() {\n  ফেরত -\"a\";\n}\nf();", "'-' অপারেটরের অপারেন্ড সংখ্যা হতে হবে, পেয়েছি স্ট্রিং\n[line 2]\nat f (line 2)\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, capturedErr := runSource(t, tt.input)
+			if capturedErr != tt.expected {
+				t.Fatalf("Expected error %q, got %q", tt.expected, capturedErr)
+			}
+		})
+	}
+}
+
+func TestStackTrace(t *testing.T) {
+	program := "ফাংশন add(a, b) {\n  ফেরত a + b;\n}\nফাংশন sum(list) {\n  ফেরত add(list[0], nil);\n}\nফাংশন main() {\n  ধরি total = sum([1]);\n  ফেরত total;\n}\nmain();"
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Nested calls", program, "'+' অপারেটর সংখ্যা ও nil যোগ করতে পারে না\n[line 2]\nat add (line 2)\nat sum (line 5)\nat main (line 8)\n"},
+		{"Uncaught throw", "ফাংশন f() {\n  নিক্ষেপ \"boom\";\n}\nফাংশন g() {\n  f();\n}\ng();", "Uncaught exception: boom\n[line 2]\nat f (line 2)\nat g (line 5)\n"},
+		{"Through a native callback", "ফাংশন f(x) {\n  ফেরত x + nil;\n}\nফাংশন g() {\n  ফেরত ম্যাপ([1], f);\n}\ng();", "'+' অপারেটর সংখ্যা ও nil যোগ করতে পারে না\n[line 2]\nat f (line 2)\nat g (line 5)\n"},
+		{"Anonymous function", "ধরি f = ফাংশন() {\n  ফেরত -\"a\";\n};\nf();", "'-' অপারেটরের অপারেন্ড সংখ্যা হতে হবে, পেয়েছি স্ট্রিং\n[line 2]\nat <anonymous> (line 2)\n"},
+		{"Top-level error has no frames", "ধরি x = 1 + nil;", "'+' অপারেটর সংখ্যা ও nil যোগ করতে পারে না\n[line 1]\n"},
+		{"Caught error leaves no trace", "ফাংশন f() { ফেরত 1 + nil; }\nচেষ্টা { f(); } ধরো (e) { }\nধরি y = -\"a\";", "'-' অপারেটরের অপারেন্ড সংখ্যা হতে হবে, পেয়েছি স্ট্রিং\n[line 3]\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, capturedErr := runSource(t, tt.input)
+			if capturedErr != tt.expected {
+				t.Fatalf("Expected error %q, got %q", tt.expected, capturedErr)
+			}
+		})
+	}
+}
+
+func TestStatisticalNatives(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{"Product", `গুণফল([2, 3, 4]);`, 24.0, ""},
+		{"Product of an empty array", `গুণফল([]);`, 1.0, ""},
+		{"Product as a method", `[1.5, 2].গুণফল();`, 3.0, ""},
+		{"Product of non-numbers", `গুণফল([2, "3"]);`, nil, "গুণফল শুধু সংখ্যার অ্যারেতে কাজ করে, 1 নম্বর ইনডেক্সে পেয়েছি স্ট্রিং"},
+		{"Product of a non-array", `গুণফল(5);`, nil, "গুণফল শুধু অ্যারেতে কাজ করে, পেয়েছি সংখ্যা"},
+		{"Median of odd length", `মধ্যমা([5, 1, 3]);`, 3.0, ""},
+		{"Median of even length", `মধ্যমা([4, 1, 3, 2]);`, 2.5, ""},
+		{"Median leaves the array unsorted", `ধরি a = [3, 1, 2]; মধ্যমা(a); a;`, []interface{}{3.0, 1.0, 2.0}, ""},
+		{"Median of an empty array", `মধ্যমা([]);`, nil, "median function expects a non-empty array"},
+		{"Mode", `মোড([1, 2, 2, 3]);`, 2.0, ""},
+		{"Mode tie goes to the first value", `মোড([3, 1, 1, 3, 2]);`, 3.0, ""},
+		{"Mode tie when the later value reaches the count first", `মোড([1, 2, 2, 1]);`, 1.0, ""},
+		{"Mode of all distinct values", `মোড([7, 8, 9]);`, 7.0, ""},
+		{"Mode of an empty array", `মোড([]);`, nil, "mode function expects a non-empty array"},
+	})
+}
+
+func TestAssignToUndefinedVariable(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Undefined variable", "ধরি a = 1;\n\nb = 2;", "Undefined variable 'b'.\n[line 3]\n"},
+		{"Target on a later line than the value starts", "ধরি a = 1;\nc =\n2;", "Undefined variable 'c'.\n[line 2]\n"},
+		{"Chained assignment with an undefined inner target", "ধরি a = 1;\na = b = 1;", "Undefined variable 'b'.\n[line 2]\n"},
+		{"Inside a function", "ফাংশন f() {\n  missing = 1;\n}\nf();", "Undefined variable 'missing'.\n[line 2]\nat f (line 2)\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, capturedErr := runSource(t, tt.input)
+			if capturedErr != tt.expected {
+				t.Fatalf("Expected error %q, got %q", tt.expected, capturedErr)
+			}
+		})
+	}
+
+	runSourceTests(t, []sourceTest{
+		{"Chained assignment leaves the outer target unchanged", `ধরি a = 1; চেষ্টা { a = b = 2; } ধরো (e) { } a;`, 1.0, ""},
+		{"Chained assignment to defined variables", `ধরি a = 1; ধরি b = 2; a = b = 3; [a, b];`, []interface{}{3.0, 3.0}, ""},
+		{"Assignment does not declare", `চেষ্টা { x = 1; } ধরো (e) { } সংজ্ঞায়িত("x");`, false, ""},
+	})
+}
+
+func TestNestedPathNatives(t *testing.T) {
+	config := `ধরি config = {server: {hosts: [{name: "a"}, {name: "b"}, {name: "c", port: 80}]}}; `
+	runSourceTests(t, []sourceTest{
+		{"Get three levels deep", config + `নেস্টেড_পাও(config, "server.hosts.2.port");`, 80.0, ""},
+		{"Get with a Bangla index", config + `নেস্টেড_পাও(config, "server.hosts.১.name");`, "b", ""},
+		{"Get a missing key", config + `নেস্টেড_পাও(config, "server.timeout");`, nil, ""},
+		{"Get past a missing key", config + `নেস্টেড_পাও(config, "client.hosts.0");`, nil, ""},
+		{"Get an index out of range", config + `নেস্টেড_পাও(config, "server.hosts.5.name");`, nil, ""},
+		{"Get inside a scalar", config + `নেস্টেড_পাও(config, "server.hosts.2.port.x");`, nil, ""},
+		{"Set three levels deep", config + `নেস্টেড_সেট(config, "server.hosts.0.port", 8080); config.server.hosts[0].port;`, 8080.0, ""},
+		{"Set creates intermediate objects", `ধরি o = {}; নেস্টেড_সেট(o, "a.b.c", 1); o.a.b.c;`, 1.0, ""},
+		{"Set returns the value", `নেস্টেড_সেট({}, "a", "v");`, "v", ""},
+		{"Set an array element", `ধরি o = {list: [1, 2, 3]}; নেস্টেড_সেট(o, "list.1", 20); o.list;`, []interface{}{1.0, 20.0, 3.0}, ""},
+		{"Non-integer array index", config + `নেস্টেড_পাও(config, "server.hosts.first");`, nil, "nested_get function cannot index an array with \"first\""},
+		{"Non-integer array index on set", config + `নেস্টেড_সেট(config, "server.hosts.x.name", 1);`, nil, "nested_set function cannot index an array with \"x\""},
+		{"Set out of range", `নেস্টেড_সেট([1], "3", 1);`, nil, "nested_set function got index 3 out of bounds for an array of length 1"},
+		{"Set inside a scalar", `নেস্টেড_সেট({a: 5}, "a.b", 1);`, nil, "nested_set function cannot set \"b\" inside 5"},
+		{"Empty segment", `নেস্টেড_পাও({}, "a..b");`, nil, "nested_get function got a malformed path \"a..b\""},
+	})
+}
+
+func TestObjectTransformNatives(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{"Map values to upper case", `মান_ম্যাপ({a: "x", b: "yz"}, বড়হাতে);`, map[string]interface{}{"a": "X", "b": "YZ"}, ""},
+		{"Map values with the key", `ফাংশন tag(v, k) { ফেরত k + "=" + v; } মান_ম্যাপ({a: 1, b: 2}, tag);`, map[string]interface{}{"a": "a=1", "b": "b=2"}, ""},
+		{"Map values leaves the original", `ধরি o = {a: 1}; ফাংশন inc(v) { ফেরত v + 1; } ধরি p = মান_ম্যাপ(o, inc); [o.a, p.a];`, []interface{}{1.0, 2.0}, ""},
+		{"Map values as a method", `ফাংশন half(v) { ফেরত v / 2; } {a: 4}.মান_ম্যাপ(half);`, map[string]interface{}{"a": 2.0}, ""},
+		{"Map keys", `ফাংশন prefix(k) { ফেরত "x_" + k; } কি_ম্যাপ({a: 1, b: 2}, prefix);`, map[string]interface{}{"x_a": 1.0, "x_b": 2.0}, ""},
+		{"Map keys with the value", `ফাংশন join(k, v) { ফেরত k + v; } কি_ম্যাপ({a: 1, b: 2}, join);`, map[string]interface{}{"a1": 1.0, "b2": 2.0}, ""},
+		{"Map keys to numbers", `ফাংশন num(k) { ফেরত 7; } কি_ম্যাপ({ab: 1}, num);`, map[string]interface{}{"7": 1.0}, ""},
+		{"Duplicate keys", `ফাংশন same(k) { ফেরত "k"; } কি_ম্যাপ({a: 1, b: 2}, same);`, nil, `map_keys function produced the key "k" more than once`},
+		{"Invalid key", `ফাংশন none(k) { ফেরত nil; } কি_ম্যাপ({a: 1}, none);`, nil, "কি_ম্যাপ: অব্জেক্ট কী স্ট্রিং বা সংখ্যা হতে হবে, পেয়েছি nil"},
+		{"Not an object", `মান_ম্যাপ([1], বড়হাতে);`, nil, "মান_ম্যাপ শুধু অব্জেক্টে কাজ করে, পেয়েছি অ্যারে"},
+		{"Not a function", `কি_ম্যাপ({a: 1}, 5);`, nil, "কি_ম্যাপ-এর দ্বিতীয় আর্গুমেন্ট ফাংশন হতে হবে, পেয়েছি সংখ্যা"},
+	})
+}
+
+func TestTimerNatives(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{
+			"Measure runs the function once and returns a non-negative time",
+			`ধরি calls = 0;
+			ফাংশন work() { calls = calls + 1; ফর (ধরি i = 0; i < 1000; i = i + 1) { } }
+			ধরি ms = সময়_মাপো(work);
+			[calls, ms >= 0];`,
+			[]interface{}{1.0, true}, "",
+		},
+		{"Monotonic never goes backwards", `ধরি a = মনোটনিক(); ধরি b = মনোটনিক(); [a >= 0, b >= a];`, []interface{}{true, true}, ""},
+		{"Measure a function with parameters", `ফাংশন f(x) { } সময়_মাপো(f);`, nil, "Expected 1 argument but got 0."},
+		{"Measure a non-function", `সময়_মাপো(1);`, nil, "সময়_মাপো শুধু ফাংশনে কাজ করে, পেয়েছি সংখ্যা"},
+		{"Measure propagates errors", `ফাংশন f() { ফেরত 1 / 0; } সময়_মাপো(f);`, nil, "Division by zero."},
+	})
+}
+
+func TestEmptinessNatives(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{"Empty array", `[খালি([]), অ_খালি([])];`, []interface{}{true, false}, ""},
+		{"Non-empty array", `[খালি([nil]), অ_খালি([nil])];`, []interface{}{false, true}, ""},
+		{"Empty string", `[খালি(""), অ_খালি("")];`, []interface{}{true, false}, ""},
+		{"Whitespace-only string is not empty", `[খালি("  "), অ_খালি("  ")];`, []interface{}{false, true}, ""},
+		{"Computed empty string", `খালি(ছোটহাতে(""));`, true, ""},
+		{"Empty object", `[খালি({}), অ_খালি({})];`, []interface{}{true, false}, ""},
+		{"Non-empty object", `[খালি({a: 1}), অ_খালি({a: 1})];`, []interface{}{false, true}, ""},
+		{"As methods", `[[].খালি(), "ক".অ_খালি(), {a: 1}.খালি()];`, []interface{}{true, true, false}, ""},
+		{"Non-collection", `খালি(0);`, nil, "খালি শুধু অ্যারে, স্ট্রিং ও অব্জেক্টে কাজ করে, পেয়েছি সংখ্যা"},
+		{"Length of a string counts characters", `লেন("বর্ণ");`, 4, ""},
+		{"Length of an object", `লেন({a: 1, b: 2});`, 2, ""},
+		{"Length of a non-collection", `লেন(5);`, nil, "লেন শুধু অ্যারে, স্ট্রিং ও অব্জেক্টে কাজ করে, পেয়েছি সংখ্যা"},
+	})
+}
+
+func TestPrintWith(t *testing.T) {
+	runOutputTests(t, outputOptions{}, []outputTest{
+		{"Custom separator", `দেখাও_সহ(", ", nil, 1, "ক", [2, 3]);`, "1, ক, [2, 3]\n"},
+		{"No newline", `দেখাও_সহ(" ", "", "a"); দেখাও_সহ(" ", "", "b");`, "ab"},
+		{"Nil keeps the defaults", `দেখাও_সহ(nil, nil, 1, 2);`, "1 2\n"},
+		{"Only the ending", `দেখাও_সহ("-", "!");`, "!"},
+		{"Empty separator", `দেখাও_সহ("", ";", "x", "y", "z");`, "xyz;"},
+		{"Multi-line ending", "দেখাও_সহ(\"+\", \"\n\", 1, 2);", "1+2\n"},
+	})
+
+	runSourceTests(t, []sourceTest{
+		{"Missing ending", `দেখাও_সহ(" ");`, nil, "Expected at least 2 arguments but got 1."},
+		{"Separator must be a string", `দেখাও_সহ(1, nil, "a");`, nil, "দেখাও_সহ-এর প্রথম আর্গুমেন্ট স্ট্রিং বা nil হতে হবে, পেয়েছি সংখ্যা"},
+	})
+}
+
+func TestBaseConversionNatives(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{"Hex", `হেক্স(255);`, "ff", ""},
+		{"Binary", `বাইনারি(10);`, "1010", ""},
+		{"Zero", `বাইনারি(0);`, "0", ""},
+		{"Hex of a bitwise result", `হেক্স(240 | 15);`, "ff", ""},
+		{"Parse hex", `পার্স_বেস("ff", 16);`, int64(255), ""},
+		{"Parse upper case", `পার্স_বেস("FF", 16);`, int64(255), ""},
+		{"Parse base 36", `পার্স_বেস("z", 36);`, int64(35), ""},
+		{"Parse negative", `পার্স_বেস("-101", 2);`, int64(-5), ""},
+		{"Hex round trip", `পার্স_বেস(হেক্স(48879), 16);`, int64(48879), ""},
+		{"Binary round trip", `পার্স_বেস(বাইনারি(12345), 2);`, int64(12345), ""},
+		{"Negative hex", `হেক্স(-1);`, nil, "হেক্স-এর প্রথম আর্গুমেন্ট অঋণাত্মক পূর্ণসংখ্যা হতে হবে, পেয়েছি -1"},
+		{"Fractional binary", `বাইনারি(1.5);`, nil, "বাইনারি-এর প্রথম আর্গুমেন্ট পূর্ণসংখ্যা হতে হবে, পেয়েছি 1.5"},
+		{"Base too small", `পার্স_বেস("1", 1);`, nil, "পার্স_বেস-এর দ্বিতীয় আর্গুমেন্ট 2 থেকে 36-এর মধ্যে হতে হবে, পেয়েছি 1"},
+		{"Base too large", `পার্স_বেস("1", 37);`, nil, "পার্স_বেস-এর দ্বিতীয় আর্গুমেন্ট 2 থেকে 36-এর মধ্যে হতে হবে, পেয়েছি 37"},
+		{"Invalid digit", `পার্স_বেস("12", 2);`, nil, `parse_base function cannot read "12" in base 2`},
+		{"Out of range", `পার্স_বেস("ffffffffffffffffff", 16);`, nil, `parse_base function's value "ffffffffffffffffff" does not fit in 64 bits`},
+		{"Non-string input", `পার্স_বেস(10, 2);`, nil, "পার্স_বেস শুধু স্ট্রিংয়ে কাজ করে, পেয়েছি সংখ্যা"},
+	})
+}
+
+func TestLoopBodyDeclarations(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{
+			"While body declares each iteration",
+			`ধরি i = 0; ধরি sum = 0; যতক্ষণ (i < 3) { ধরি x = i * 2; sum = sum + x; i = i + 1; } sum;`,
+			6.0, "",
+		},
+		{
+			"For body declares each iteration",
+			`ধরি sum = 0; ফর (ধরি i = 0; i < 3; i = i + 1) { ধরি x = i; sum = sum + x; } sum;`,
+			3.0, "",
+		},
+		{
+			"For body declares a name the initializer uses in an outer loop",
+			`ধরি n = 0; ফর (ধরি i = 0; i < 2; i = i + 1) { ফর (ধরি j = 0; j < 2; j = j + 1) { ধরি i = j; n = n + 1; } } n;`,
+			4.0, "",
+		},
+		{
+			"Foreach body declares each iteration",
+			`ধরি out = []; প্রত্যেক (v ইন [1, 2, 3]) { ধরি doubled = v * 2; out = এড(out, doubled); } out;`,
+			[]interface{}{2.0, 4.0, 6.0}, "",
+		},
+		{
+			"Declaration after continue point",
+			`ধরি i = 0; ধরি seen = 0; যতক্ষণ (i < 4) { i = i + 1; ধরি c = i; যদি (c % 2 == 0) { চালিয়ে_যাও; } seen = seen + c; } seen;`,
+			4.0, "",
+		},
+		{
+			"Function declared in a loop body",
+			`ধরি fs = []; ধরি i = 0; যতক্ষণ (i < 3) { ধরি v = i; ফাংশন get() { ফেরত v; } fs = এড(fs, get); i = i + 1; } [fs[0](), fs[2]()];`,
+			[]interface{}{0.0, 2.0}, "",
+		},
+		{
+			"Redeclaring within one iteration is still an error",
+			`ধরি i = 0; যতক্ষণ (i < 1) { ধরি x = 1; ধরি x = 2; i = i + 1; }`,
+			nil, "Cannot redeclare variable x.",
+		},
+	})
+}
+
+// unhandledNode is an AST node the interpreter has no case for.
+type unhandledNode struct{}
+
+func (u *unhandledNode) String() string {
+	return "unhandled"
+}
+
+func TestUnhandledNodeIsAnInternalError(t *testing.T) {
+	utils.HadError = false
+	utils.HadRuntimeError = false
+
+	i := NewInterpreter()
+	stderr := CaptureStderr(func() {
+		i.Interpret([]ast.Stmt{&unhandledNode{}}, false)
+	})
+	if !utils.HadRuntimeError {
+		t.Fatalf("Expected a runtime error")
+	}
+	if !strings.Contains(stderr, "Internal error") || !strings.Contains(stderr, "*interpreter.unhandledNode") {
+		t.Fatalf("Expected an internal error naming the node type, got %q", stderr)
+	}
+}
+
+func TestArrowFunctions(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{"Map with an arrow function", `ম্যাপ([1, 2, 3], ফাংশন(x) => x + 1);`, []interface{}{2.0, 3.0, 4.0}, ""},
+		{"Filter with an index", `ফিল্টার([5, 6, 7], ফাংশন(x, i) => i != 1);`, []interface{}{5.0, 7.0}, ""},
+		{"Stored and called", `ধরি add = ফাংশন(a, b) => a + b; add(2, 3);`, 5.0, ""},
+		{"Called directly", `(ফাংশন(x) => x * 2)(21);`, 42.0, ""},
+		{"Closes over its scope", `ধরি adder = ফাংশন(n) => ফাংশন(x) => x + n; adder(10)(5);`, 15.0, ""},
+		{"No parameters", `ধরি f = ফাংশন() => "ok"; f();`, "ok", ""},
+		{"Arity is checked", `ধরি f = ফাংশন(x) => x; f(1, 2);`, nil, "Expected 1 argument but got 2."},
+	})
+}
+
+func TestFunctionsInObjects(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{"Block body", `ধরি f = ফাংশন(x) { ধরি y = x * 2; ফেরত y + 1; }; f(3);`, 7.0, ""},
+		{"Block body without return", `ধরি f = ফাংশন() { }; f();`, nil, ""},
+		{"Called through a property", `ধরি o = {greet: ফাংশন(name) { ফেরত "hi " + name; }}; o.greet("borno");`, "hi borno", ""},
+		{"Called through brackets", `ধরি o = {double: ফাংশন(x) => x * 2}; o["double"](4);`, 8.0, ""},
+		{"Assigned after creation", `ধরি o = {}; o.inc = ফাংশন(x) { ফেরত x + 1; }; o.inc(1);`, 2.0, ""},
+		{"Passed to map", `ধরি o = {square: ফাংশন(x) { ফেরত x * x; }}; ম্যাপ([1, 2, 3], o.square);`, []interface{}{1.0, 4.0, 9.0}, ""},
+		{"Nested object", `ধরি o = {math: {add: ফাংশন(a, b) => a + b}}; o.math.add(2, 3);`, 5.0, ""},
+		{"Missing method", `ধরি o = {}; o.greet();`, nil, "Property 'greet' does not exist on object 'o'."},
+	})
+}
+
+func TestObjectKeysAreNormalized(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{"Dot and literal bracket", `ধরি o = {a: 1}; o["a"];`, 1.0, ""},
+		{"Computed key reads a literal key", `ধরি o = {নাম: "ক"}; o["না" + "ম"];`, "ক", ""},
+		{"Computed key writes a dot property", `ধরি o = {}; o["x" + "y"] = 2; o.xy;`, 2.0, ""},
+		{"String literal key reads by dot", `ধরি o = {"b": 3}; o.b;`, 3.0, ""},
+		{"Number and string index agree", `ধরি o = {}; o[1] = "one"; o["1"];`, "one", ""},
+		{"Delete with a computed key", `ধরি o = {ab: 1, c: 2}; কি_রিমুভ(o, "a" + "b"); অব্জেক্ট_কি(o);`, []interface{}{"c"}, ""},
+		{"Delete with a number key", `ধরি o = {}; o["2"] = 1; কি_রিমুভ(o, 2); লেন(o);`, 0, ""},
+		{"Delete with an invalid key", `কি_রিমুভ({}, সত্য);`, nil, "কি_রিমুভ: অব্জেক্ট কী স্ট্রিং বা সংখ্যা হতে হবে, পেয়েছি বুলিয়ান"},
+	})
+}
+
+func TestRange(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{"End only", `[...পরিসর(4)];`, []interface{}{0.0, 1.0, 2.0, 3.0}, ""},
+		{"Start and end", `[...পরিসর(2, 5)];`, []interface{}{2.0, 3.0, 4.0}, ""},
+		{"Negative step", `[...পরিসর(5, 0, -2)];`, []interface{}{5.0, 3.0, 1.0}, ""},
+		{"Fractional step", `[...পরিসর(0, 1, 0.25)];`, []interface{}{0.0, 0.25, 0.5, 0.75}, ""},
+		{"Empty when the end is behind the start", `[...পরিসর(3, 1)];`, []interface{}{}, ""},
+		{"Foreach sums a large range", `ধরি s = 0; প্রত্যেক (x ইন পরিসর(100000)) { s = s + x; } s;`, 4999950000.0, ""},
+		{"Foreach stops early on a huge range", `ধরি n = 0; প্রত্যেক (x ইন পরিসর(1000000000000)) { যদি (x == 3) { থামো; } n = n + 1; } n;`, 3.0, ""},
+		{"Walked again from the start", `ধরি r = পরিসর(3); ধরি s = 0; প্রত্যেক (x ইন r) { s = s + x; } প্রত্যেক (x ইন r) { s = s + x; } s;`, 6.0, ""},
+		{"Materialized for natives", `ম্যাপ(পরিসর(3), ফাংশন(x) => x * 10);`, []interface{}{0.0, 10.0, 20.0}, ""},
+		{"Materialized for methods", `পরিসর(1, 4).ম্যাপ(ফাংশন(x) => x * x);`, []interface{}{1.0, 4.0, 9.0}, ""},
+		{"Length", `লেন(পরিসর(0, 10, 3));`, 4, ""},
+		{"Indexed", `পরিসর(10, 20)[3];`, 13.0, ""},
+		{"Spread", `[...পরিসর(2), 5];`, []interface{}{0.0, 1.0, 5.0}, ""},
+		{"Zero step", `পরিসর(0, 5, 0);`, nil, "range function's step cannot be zero"},
+		{"Not a number", `পরিসর("a");`, nil, "range function expects finite numbers, got \"a\""},
+	})
+}
+
+func TestRangeIteratesLazily(t *testing.T) {
+	iterator := (&Range{Start: 0, End: 1e15, Step: 1}).Iterate()
+	for expected := 0.0; expected < 3; expected++ {
+		value, ok := iterator.Next()
+		if !ok || value != expected {
+			t.Fatalf("Expected %v, got %v (ok %v)", expected, value, ok)
+		}
+	}
+
+	iterator = (&Range{Start: 0, End: 2, Step: 1}).Iterate()
+	iterator.Next()
+	iterator.Next()
+	if _, ok := iterator.Next(); ok {
+		t.Fatalf("Expected the range to be exhausted")
+	}
+}
+
+func TestTemplate(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{"Multiple keys", `টেমপ্লেট("{name} is {age} years old", {name: "রহিম", age: 30});`, "রহিম is 30 years old", ""},
+		{"Repeated key", `টেমপ্লেট("{x}-{x}", {x: 1.5});`, "1.5-1.5", ""},
+		{"Values are stringified", `টেমপ্লেট("{a} {o} {n} {b}", {a: [1, "x"], o: {k: 2}, n: nil, b: সত্য});`, `[1, "x"] {k: 2} nil true`, ""},
+		{"Missing key is left alone", `টেমপ্লেট("Hi {name}, {missing}", {name: "ক"});`, "Hi ক, {missing}", ""},
+		{"Missing key in strict mode", `টেমপ্লেট("{missing}", {}, সত্য);`, nil, "template function has no value for {missing}"},
+		{"Escaped braces", `টেমপ্লেট("{{name}} is {name}, }}", {name: "x"});`, "{name} is x, }", ""},
+		{"Unclosed placeholder", `টেমপ্লেট("a {b", {b: 1});`, "a {b", ""},
+		{"Unclosed placeholder in strict mode", `টেমপ্লেট("a {b", {b: 1}, সত্য);`, nil, "template function found an unclosed placeholder"},
+		{"As a method", `"{a}+{b}".টেমপ্লেট({a: 1, b: 2});`, "1+2", ""},
+		{"Values must be an object", `টেমপ্লেট("{a}", [1]);`, nil, "টেমপ্লেট-এর দ্বিতীয় আর্গুমেন্ট অব্জেক্ট হতে হবে, পেয়েছি অ্যারে"},
+	})
+}
+
+func TestSortBy(t *testing.T) {
+	people := `ধরি people = [{name: "গ", age: 30}, {name: "ক", age: 25}, {name: "খ", age: 30}, {name: "ঘ", age: 25}]; `
+	names := `; ম্যাপ(sorted, ফাংশন(p) => p.name);`
+	runSourceTests(t, []sourceTest{
+		{"By a numeric field, stable for equal keys", people + `ধরি sorted = সাজাও_দ্বারা(people, ফাংশন(p) => p.age)` + names, []interface{}{"ক", "ঘ", "গ", "খ"}, ""},
+		{"By a string field", people + `ধরি sorted = সাজাও_দ্বারা(people, ফাংশন(p) => p.name)` + names, []interface{}{"ক", "খ", "গ", "ঘ"}, ""},
+		{"Descending by key, stable for equal keys", people + `ধরি sorted = বিপরীত_সাজাও(people, ফাংশন(p) => p.age)` + names, []interface{}{"গ", "খ", "ক", "ঘ"}, ""},
+		{"Descending by value", `বিপরীত_সাজাও([3, 1, 2]);`, []interface{}{3.0, 2.0, 1.0}, ""},
+		{"Leaves the array unchanged", `ধরি a = [3, 1, 2]; সাজাও_দ্বারা(a, ফাংশন(x) => x); a;`, []interface{}{3.0, 1.0, 2.0}, ""},
+		{"Key function runs once per element", `ধরি calls = 0; সাজাও_দ্বারা([5, 3, 8, 1, 9, 2], ফাংশন(x) { calls = calls + 1; ফেরত x; }); calls;`, 6.0, ""},
+		{"Key function receives the index", `সাজাও_দ্বারা(["a", "b", "c"], ফাংশন(x, i) => -i);`, []interface{}{"c", "b", "a"}, ""},
+		{"As a method", `[3, 1, 2].সাজাও_দ্বারা(ফাংশন(x) => x);`, []interface{}{1.0, 2.0, 3.0}, ""},
+		{"Empty array", `সাজাও_দ্বারা([], ফাংশন(x) => x);`, []interface{}{}, ""},
+		{"Mixed keys", `সাজাও_দ্বারা([1, "a"], ফাংশন(x) => x);`, nil, `sort_by function cannot compare 1 with "a"`},
+		{"Unorderable keys", `বিপরীত_সাজাও([[1], [2]]);`, nil, "sort_descending function can only sort by numbers or strings, got [1]"},
+	})
+}
+
+func TestSnapshotAndRestore(t *testing.T) {
+	utils.HadError = false
+	utils.HadRuntimeError = false
+
+	i := NewInterpreter()
+	run := func(source string) interface{} {
+		t.Helper()
+		tokens, _ := lexer.NewScanner([]rune(source)).ScanTokens()
+		stmts, _ := parser.NewParser(tokens).Parse()
+		results := i.Interpret(stmts, false)
+		if utils.HadError || utils.HadRuntimeError {
+			t.Fatalf("Unexpected error running %q", source)
+		}
+		if len(results) == 0 {
+			return nil
+		}
+		return results[len(results)-1]
+	}
+
+	run(`ধরি x = 1; ধরি list = [1]; ফাংশন getX() { ফেরত x; }`)
+	snapshot := i.Snapshot()
+
+	run(`x = 2; ধরি y = 3; list = এড(list, 2);`)
+	if got := run(`getX();`); got != 2.0 {
+		t.Fatalf("Expected x to be 2 before restoring, got %v", got)
+	}
+
+	if err := i.Restore(snapshot); err != nil {
+		t.Fatalf("Unexpected restore error: %v", err)
+	}
+	if got := run(`x;`); got != 1.0 {
+		t.Fatalf("Expected x to be restored to 1, got %v", got)
+	}
+	if got := run(`getX();`); got != 1.0 {
+		t.Fatalf("Expected a closure to see the restored x, got %v", got)
+	}
+	if got := run(`সংজ্ঞায়িত("y");`); got != false {
+		t.Fatalf("Expected y to be removed, got %v", got)
+	}
+	if got := run(`list;`); !reflect.DeepEqual(got, []interface{}{1.0}) {
+		t.Fatalf("Expected the reassigned array to be restored, got %v", got)
+	}
+
+	// The snapshot is shallow, so changing an array in place is kept
+	run(`list[0] = 9;`)
+	if err := i.Restore(snapshot); err != nil {
+		t.Fatalf("Unexpected restore error: %v", err)
+	}
+	if got := run(`list;`); !reflect.DeepEqual(got, []interface{}{9.0}) {
+		t.Fatalf("Expected the in-place change to survive, got %v", got)
+	}
+
+	if err := NewInterpreter().Restore(snapshot); err == nil {
+		t.Fatalf("Expected an error restoring another interpreter's snapshot")
+	}
+}
+
+func TestMergePickOmit(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{"Merge with overlapping keys", `একত্র({a: 1, b: 2}, {b: 3, c: 4}, {c: 5});`, map[string]interface{}{"a": 1.0, "b": 3.0, "c": 5.0}, ""},
+		{"Merge leaves its inputs alone", `ধরি x = {a: 1}; একত্র(x, {a: 2, b: 3}); x;`, map[string]interface{}{"a": 1.0}, ""},
+		{"Merge returns a new object", `ধরি x = {a: 1}; ধরি y = একত্র(x); y.a = 2; x.a;`, 1.0, ""},
+		{"Merge a non-object", `একত্র({a: 1}, [1]);`, nil, "একত্র শুধু অব্জেক্টে কাজ করে, পেয়েছি অ্যারে (আর্গুমেন্ট 2)"},
+		{"Pick listed keys", `নির্বাচন({a: 1, b: 2, c: 3}, ["a", "c"]);`, map[string]interface{}{"a": 1.0, "c": 3.0}, ""},
+		{"Pick a missing key", `নির্বাচন({a: 1}, ["a", "missing"]);`, map[string]interface{}{"a": 1.0}, ""},
+		{"Pick with a number key", `ধরি o = {}; o[1] = "one"; নির্বাচন(o, [1]);`, map[string]interface{}{"1": "one"}, ""},
+		{"Omit listed keys", `বাদ({a: 1, b: 2, c: 3}, ["b"]);`, map[string]interface{}{"a": 1.0, "c": 3.0}, ""},
+		{"Omit all keys", `বাদ({a: 1, b: 2}, ["a", "b"]);`, map[string]interface{}{}, ""},
+		{"Omit leaves its input alone", `ধরি x = {a: 1}; বাদ(x, ["a"]); x;`, map[string]interface{}{"a": 1.0}, ""},
+		{"As methods", `ধরি o = {a: 1, b: 2}; [o.নির্বাচন(["a"]), o.বাদ(["a"]), o.একত্র({c: 3})];`, []interface{}{map[string]interface{}{"a": 1.0}, map[string]interface{}{"b": 2.0}, map[string]interface{}{"a": 1.0, "b": 2.0, "c": 3.0}}, ""},
+		{"Keys must be an array", `নির্বাচন({a: 1}, "a");`, nil, "নির্বাচন-এর দ্বিতীয় আর্গুমেন্ট অ্যারে হতে হবে, পেয়েছি স্ট্রিং"},
+		{"Invalid key", `বাদ({a: 1}, [nil]);`, nil, "বাদ: অব্জেক্ট কী স্ট্রিং বা সংখ্যা হতে হবে, পেয়েছি nil"},
+	})
+}
+
+func TestCircularStringify(t *testing.T) {
+	runOutputTests(t, outputOptions{}, []outputTest{
+		{"Object containing itself", `ধরি o = {name: "a"}; o.self = o; দেখাও(o);`, "{name: \"a\", self: [circular]}\n"},
+		{"Array containing itself", `ধরি a = [0]; a[0] = a; দেখাও(a);`, "[[circular]]\n"},
+		{"Indirect cycle", `ধরি p = {}; ধরি q = {p: p}; p.q = q; দেখাও(q);`, "{p: {q: [circular]}}\n"},
+		{"Shared reference is not a cycle", `ধরি x = {x: 1}; দেখাও([x, x]);`, "[{x: 1}, {x: 1}]\n"},
+	})
+}
+
+func TestArrayConcatenation(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{"Two arrays", "[1, 2] + [3, 4];", []interface{}{1.0, 2.0, 3.0, 4.0}, ""},
+		{"Empty arrays", "[] + [];", []interface{}{}, ""},
+		{"Nested arrays stay nested", "[[1]] + [[2]];", []interface{}{[]interface{}{1.0}, []interface{}{2.0}}, ""},
+		{"Operands are not modified", "ধরি a = [1]; ধরি b = a + [2]; b[0] = 9; a;", []interface{}{1.0}, ""},
+		{"Reassignment", "ধরি a = [1]; a = a + [2, 3]; a;", []interface{}{1.0, 2.0, 3.0}, ""},
+		{"Array plus number", "[1, 2] + 3;", nil, "'+' অপারেটরের ডান অপারেন্ড অ্যারে হতে হবে, পেয়েছি সংখ্যা"},
+		{"Array plus string", `[1] + "a";`, nil, "'+' অপারেটরের ডান অপারেন্ড অ্যারে হতে হবে, পেয়েছি স্ট্রিং"},
+		{"Number plus array", "1 + [2];", nil, "'+' অপারেটর সংখ্যা ও অ্যারে যোগ করতে পারে না"},
+	})
+}
+
+func TestRecursionThroughTheDeclaredName(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{"Recursive factorial", `
+			ফাংশন fact(n) {
+				যদি (n <= 1) { ফেরত 1; }
+				ফেরত n * fact(n - 1);
+			}
+			fact(5);`, 120.0, ""},
+		{"Anonymous function recurses through its variable", `
+			ধরি fact = ফাংশন(n) {
+				যদি (n <= 1) { ফেরত 1; }
+				ফেরত n * fact(n - 1);
+			};
+			fact(5);`, 120.0, ""},
+		{"Mutual recursion", `
+			ফাংশন even(n) {
+				যদি (n == 0) { ফেরত সত্য; }
+				ফেরত odd(n - 1);
+			}
+			ফাংশন odd(n) {
+				যদি (n == 0) { ফেরত মিথ্যা; }
+				ফেরত even(n - 1);
+			}
+			even(10);`, true, ""},
+		{"Recursive calls go through a reassigned name", `
+			ধরি calls = 0;
+			ফাংশন fact(n) {
+				যদি (n <= 1) { ফেরত 1; }
+				ফেরত n * fact(n - 1);
+			}
+			ধরি original = fact;
+			fact = ফাংশন(n) {
+				calls = calls + 1;
+				ফেরত original(n);
+			};
+			fact(4);
+			calls;`, 4.0, ""},
+		{"Inner function shadows an outer one of the same name", `
+			ফাংশন count(n) { ফেরত "outer"; }
+			ফাংশন run() {
+				ফাংশন count(n) {
+					যদি (n == 0) { ফেরত "inner"; }
+					ফেরত count(n - 1);
+				}
+				ফেরত count(3);
+			}
+			run() + " " + count(3);`, "inner outer", ""},
+	})
+}
+
+func TestTypeNames(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{"Number", `ধরন(1.5);`, "সংখ্যা", ""},
+		{"Big integer", `ধরন(2 ** 80);`, "সংখ্যা", ""},
+		{"String", `ধরন("ক");`, "স্ট্রিং", ""},
+		{"Boolean", `ধরন(সত্য);`, "বুলিয়ান", ""},
+		{"Nil", `ধরন(nil);`, "nil", ""},
+		{"Array", `ধরন([1]);`, "অ্যারে", ""},
+		{"Object", `ধরন({a: 1});`, "অব্জেক্ট", ""},
+		{"User function", `ফাংশন f() {} ধরন(f);`, "ফাংশন", ""},
+		{"Native function", `ধরন(লেন);`, "ফাংশন", ""},
+		{"Range", `ধরন(পরিসর(3));`, "পরিসর", ""},
+		{"Materialized range", `ধরন([...পরিসর(3)]);`, "অ্যারে", ""},
+		{"Missing argument", `ধরন();`, nil, "Expected 1 argument but got 0."},
+	})
+}
+
+func TestTypeErrorsNameTheType(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{"Length of a boolean", `লেন(সত্য);`, nil, "লেন শুধু অ্যারে, স্ট্রিং ও অব্জেক্টে কাজ করে, পেয়েছি বুলিয়ান"},
+		{"Indexing a number", `ধরি x = 5; x[0];`, nil, "ইনডেক্স শুধু অ্যারে ও অব্জেক্টে কাজ করে, পেয়েছি সংখ্যা"},
+		{"Index assignment on a string", `ধরি s = "ক"; s[0] = "খ";`, nil, "ইনডেক্সে মান বসানো শুধু অ্যারে ও অব্জেক্টে কাজ করে, পেয়েছি স্ট্রিং"},
+		{"Array index of the wrong type", `ধরি a = [1]; a[nil];`, nil, "অ্যারে ইনডেক্স পূর্ণসংখ্যা হতে হবে, পেয়েছি nil"},
+		{"Property assignment on an array", `ধরি a = [1]; a.x = 2;`, nil, "প্রপার্টি সেট করা শুধু অব্জেক্টে কাজ করে, পেয়েছি অ্যারে"},
+		{"Calling a string", `"ক"();`, nil, "শুধু ফাংশন কল করা যায়, পেয়েছি স্ট্রিং"},
+		{"Spreading an object", `[...{a: 1}];`, nil, "স্প্রেড শুধু অ্যারেতে কাজ করে, পেয়েছি অব্জেক্ট"},
+		{"Math native", `পরমমান("ক");`, nil, "পরমমান শুধু সংখ্যায় কাজ করে, পেয়েছি স্ট্রিং"},
+		{"Later math argument", `ঘাত(2, [3]);`, nil, "ঘাত-এর দ্বিতীয় আর্গুমেন্ট সংখ্যা হতে হবে, পেয়েছি অ্যারে"},
+		{"Any of several numbers", `সর্বোচ্চ(1, সত্য);`, nil, "সর্বোচ্চ শুধু সংখ্যায় কাজ করে, পেয়েছি বুলিয়ান"},
+		{"Integer argument", `স্লাইস([1, 2], 0, "শেষ");`, nil, "স্লাইস-এর তৃতীয় আর্গুমেন্ট পূর্ণসংখ্যা হতে হবে, পেয়েছি স্ট্রিং"},
+		{"Index out of range", `রিমুভ([1], 3);`, nil, "রিমুভ-এর ইনডেক্স 3 অ্যারের সীমার বাইরে"},
+		{"String argument", `ভাঙো("a,b", 1);`, nil, "ভাঙো-এর দ্বিতীয় আর্গুমেন্ট স্ট্রিং হতে হবে, পেয়েছি সংখ্যা"},
+		{"Boolean argument", `টেমপ্লেট("{a}", {a: 1}, 1);`, nil, "টেমপ্লেট-এর তৃতীয় আর্গুমেন্ট বুলিয়ান হতে হবে, পেয়েছি সংখ্যা"},
+		{"Print ending", `দেখাও_সহ(nil, 1);`, nil, "দেখাও_সহ-এর দ্বিতীয় আর্গুমেন্ট স্ট্রিং বা nil হতে হবে, পেয়েছি সংখ্যা"},
+		{"Path", `নেস্টেড_পাও({}, 1);`, nil, "নেস্টেড_পাও-এর দ্বিতীয় আর্গুমেন্ট স্ট্রিং হতে হবে, পেয়েছি সংখ্যা"},
+		{"Pattern", `মেলে("a", nil);`, nil, "মেলে-এর দ্বিতীয় আর্গুমেন্ট স্ট্রিং হতে হবে, পেয়েছি nil"},
+		{"Number conversion", `সংখ্যায়([1]);`, nil, "সংখ্যায় শুধু সংখ্যা ও স্ট্রিংয়ে কাজ করে, পেয়েছি অ্যারে"},
+		{"Left operand", `"ক" * 2;`, nil, "'*' অপারেটরের বাম অপারেন্ড সংখ্যা হতে হবে, পেয়েছি স্ট্রিং"},
+		{"Right operand", `2 ** {};`, nil, "'**' অপারেটরের ডান অপারেন্ড সংখ্যা হতে হবে, পেয়েছি অব্জেক্ট"},
+		{"Integer operand", `1 & "ক";`, nil, "'&' অপারেটরের ডান অপারেন্ড পূর্ণসংখ্যা হতে হবে, পেয়েছি স্ট্রিং"},
+		{"Unary operand", `-[1];`, nil, "'-' অপারেটরের অপারেন্ড সংখ্যা হতে হবে, পেয়েছি অ্যারে"},
+	})
+}
+
+func TestDiscardIdentifier(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{"Redeclaring is allowed", `ধরি _ = 1; ধরি _ = 2; ধরি x = 3; x;`, 3.0, ""},
+		{"Initializer still runs", `ধরি n = 0; ফাংশন bump() { n = n + 1; } ধরি _ = bump(); ধরি _ = bump(); n;`, 2.0, ""},
+		{"Declaration list", `ধরি _ = 1, b = 2, _ = 3; b;`, 2.0, ""},
+		{"Assignment needs no declaration", `_ = 5;`, 5.0, ""},
+		{"Array pattern slot", `ধরি arr = [1, 2]; ধরি [_, b] = arr; b;`, 2.0, ""},
+		{"Several pattern slots", `ধরি [_, _, c] = [1, 2, 3]; ধরি [_, d] = [4, 5]; [c, d];`, []interface{}{3.0, 5.0}, ""},
+		{"Loop variable", `ধরি count = 0; প্রত্যেক (_ ইন [1, 2, 3]) { count = count + 1; } count;`, 3.0, ""},
+		{"Nested loops", `ধরি count = 0; প্রত্যেক (_ ইন [1, 2]) { প্রত্যেক (_ ইন [1, 2, 3]) { count = count + 1; } } count;`, 6.0, ""},
+		{"Repeated parameter", `ফাংশন second(_, b, _) { ফেরত b; } second(1, 2, 3);`, 2.0, ""},
+		{"Nothing is stored", `ধরি _ = 1; _;`, nil, "Variable _ is not defined."},
+	})
+}
+
+func TestNumericStrings(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{"Negative Bangla decimal", `সংখ্যায়("-৩.৫");`, -3.5, ""},
+		{"Unicode minus sign", "সংখ্যায়(\"\u2212৭\");", -7.0, ""},
+		{"En dash as minus", "সংখ্যায়(\"\u2013২.২৫\");", -2.25, ""},
+		{"Fullwidth plus sign", "সংখ্যায়(\"\uFF0B৯\");", 9.0, ""},
+		{"Surrounding whitespace", "সংখ্যায়(\" \t১২\n\");", 12.0, ""},
+		{"Mixed digits", `সংখ্যায়(" -১2.5 ");`, -12.5, ""},
+		{"Whitespace inside is still invalid", `সংখ্যায়("১ ২");`, nil, `সংখ্যায়-এর প্রথম আর্গুমেন্ট সংখ্যা লেখা স্ট্রিং হতে হবে, পেয়েছি "১ ২"`},
+		{"Doubled sign is still invalid", `সংখ্যায়("--৩");`, nil, `সংখ্যায়-এর প্রথম আর্গুমেন্ট সংখ্যা লেখা স্ট্রিং হতে হবে, পেয়েছি "--৩"`},
+		{"Integer conversion", "[10, 20, 30][\" ১ \"];", 20.0, ""},
+		{"Negative string in arithmetic", "২ * \"\u2212৪\";", -8.0, ""},
+	})
+}
+
+func TestHasKey(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{"Present key", `আছে_কি({a: 1}, "a");`, true, ""},
+		{"Absent key", `আছে_কি({a: 1}, "b");`, false, ""},
+		{"Key holding nil", `আছে_কি({a: nil}, "a");`, true, ""},
+		{"Number key is normalized", `ধরি o = {}; o[1] = "x"; আছে_কি(o, 1) && আছে_কি(o, "1");`, true, ""},
+		{"After deletion", `ধরি o = {a: 1}; কি_রিমুভ(o, "a"); আছে_কি(o, "a");`, false, ""},
+		{"As a method", `ধরি o = {a: 1}; o.আছে_কি("a");`, true, ""},
+		{"Guards a lookup", `ধরি o = {}; ধরি v = "none"; যদি (আছে_কি(o, "x")) { v = o.x; } v;`, "none", ""},
+		{"Not an object", `আছে_কি([1], 0);`, nil, "আছে_কি শুধু অব্জেক্টে কাজ করে, পেয়েছি অ্যারে"},
+		{"Invalid key", `আছে_কি({}, nil);`, nil, "আছে_কি: অব্জেক্ট কী স্ট্রিং বা সংখ্যা হতে হবে, পেয়েছি nil"},
+	})
+}
+
+func TestForLoopWithSeveralIncrements(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{"Two variables meet in the middle", `
+			ধরি pairs = [];
+			ফর (ধরি i = 0, j = 4; i < j; i = i + 1, j = j - 1) {
+				pairs = এড(pairs, [i, j]);
+			}
+			pairs;`, []interface{}{[]interface{}{0.0, 4.0}, []interface{}{1.0, 3.0}}, ""},
+		{"Increments run left to right", `
+			ধরি a = 1;
+			ধরি b = 0;
+			ফর (ধরি i = 0; i < 3; i = i + 1, a = a * 2, b = b + a) {}
+			[a, b];`, []interface{}{8.0, 14.0}, ""},
+		{"Continue still runs every increment", `
+			ধরি s = 0;
+			ফর (ধরি i = 0, j = 10; i < 4; i = i + 1, j = j - 1) {
+				যদি (i == 1) { চালিয়ে_যাও; }
+				s = s + j;
+			}
+			s;`, 25.0, ""},
+		{"Error in a later increment", `ফর (ধরি i = 0; i < 3; i = i + 1, i = i + nil) {}`, nil, "'+' অপারেটর সংখ্যা ও nil যোগ করতে পারে না"},
+	})
+}
+
+func TestArrayPattern(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{"Binds by position", `ধরি [a, b, c] = [1, "দুই", [3]]; [c, b, a];`, []interface{}{[]interface{}{3.0}, "দুই", 1.0}, ""},
+		{"Missing elements are nil", `ধরি [a, b] = [1]; [a, b];`, []interface{}{1.0, nil}, ""},
+		{"Extra elements are ignored", `ধরি [a] = [1, 2, 3]; a;`, 1.0, ""},
+		{"Empty pattern", `ধরি [] = [1]; 0;`, 0.0, ""},
+		{"Within a declaration list", `ধরি [a, b] = [1, 2], c = a + b; c;`, 3.0, ""},
+		{"From a range", `ধরি [a, b] = পরিসর(10, 20); [a, b];`, []interface{}{10.0, 11.0}, ""},
+		{"In a for initializer", `ধরি s = 0; ফর (ধরি [i, n] = [0, 3]; i < n; i = i + 1) { s = s + i; } s;`, 3.0, ""},
+		{"Not an array", `ধরি [a] = 5;`, nil, "অ্যারে প্যাটার্ন শুধু অ্যারে ভাঙতে পারে, পেয়েছি সংখ্যা"},
+		{"Redeclaration", `ধরি a = 1; ধরি [a] = [2];`, nil, "Cannot redeclare variable a."},
+		{"Repeated name", `ধরি [a, a] = [1, 2];`, nil, "Cannot redeclare variable a."},
+	})
+}
+
+func TestContainerEquality(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{"Arrays with different elements", `[1] == [2];`, false, ""},
+		{"Empty arrays are equal", `ধরি c = []; ধরি d = []; c == d;`, true, ""},
+		{"Empty array and non-empty array", `ধরি c = []; c == [nil];`, false, ""},
+		{"Empty objects are equal", `ধরি o = {}; o == {};`, true, ""},
+		{"Objects with different values", `ধরি o = {a: 1}; o == {a: 2};`, false, ""},
+		{"Nested containers", `[{a: [1]}] == [{a: [1]}];`, true, ""},
+		{"Array and object", `ধরি o = {}; [] != o;`, true, ""},
+		{"Cyclic arrays", `ধরি a = [0]; a[0] = a; ধরি b = [0]; b[0] = b; a == b;`, true, ""},
+	})
+}
+
+func TestLiteralAndComputedStringsAgree(t *testing.T) {
+	literal := `ধরি a = "বাংলা"; ধরি b = "বাং" + "লা"; `
+	runSourceTests(t, []sourceTest{
+		{"Equal", literal + `a == b;`, true, ""},
+		{"Same length", literal + `[লেন(a), লেন(b)];`, []interface{}{5, 5}, ""},
+		{"Concatenate both ways", literal + `[a + b, b + a];`, []interface{}{"বাংলাবাংলা", "বাংলাবাংলা"}, ""},
+		{"Literal is a Go string", `"ক";`, "ক", ""},
+		{"Same object key", literal + `ধরি o = {}; o[a] = 1; o[b];`, 1.0, ""},
+		{"Same switch case", literal + `ধরি r = 0; সুইচ (b) { ক্ষেত্রে a: r = 1; } r;`, 1.0, ""},
+		{"Same characters", literal + `ধরি x = []; প্রত্যেক (c ইন b) { x = এড(x, c); } x;`, []interface{}{"ব", "া", "ং", "ল", "া"}, ""},
+		{"Same coercion", `["5" * 2, ("" + "5") * 2];`, []interface{}{10.0, 10.0}, ""},
+		{"Same method", literal + `[a.বড়হাতে(), b.বড়হাতে()];`, []interface{}{"বাংলা", "বাংলা"}, ""},
+		{"Stored in an array unchanged", `ধরি s = "ক"; [s, s + ""];`, []interface{}{"ক", "ক"}, ""},
+	})
+}
+
+func TestMaxLoopIterations(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		errorMsg string
+	}{
+		{"Infinite while", `যতক্ষণ (সত্য) { }`, "Loop iteration limit exceeded."},
+		{"Infinite for", `ফর (;;) { }`, "Loop iteration limit exceeded."},
+		{"Long foreach", `প্রত্যেক (x ইন [1, 2, 3, 4, 5, 6]) { }`, "Loop iteration limit exceeded."},
+		{"While within the limit", `ধরি n = 0; যতক্ষণ (n < 5) { n = n + 1; }`, ""},
+		{"Each loop has its own count", `ফর (ধরি a = 0; a < 5; a = a + 1) { ফর (ধরি b = 0; b < 5; b = b + 1) { } }`, ""},
+		{"Foreach within the limit", `প্রত্যেক (x ইন [1, 2, 3, 4, 5]) { }`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			utils.HadError = false
+			utils.HadRuntimeError = false
+
+			i := NewInterpreter()
+			i.MaxLoopIterations = 5
+			stderr := CaptureStderr(func() {
+				tokens, _ := lexer.NewScanner([]rune(tt.input)).ScanTokens()
+				stmts, _ := parser.NewParser(tokens).Parse()
+				i.Interpret(stmts, false)
+			})
+			if tt.errorMsg == "" {
+				if utils.HadRuntimeError {
+					t.Fatalf("Unexpected error: %s", stderr)
+				}
+				return
+			}
+			if !utils.HadRuntimeError || strings.Split(stderr, "\n")[0] != tt.errorMsg {
+				t.Fatalf("Expected error %q, got %q", tt.errorMsg, stderr)
+			}
+			if !strings.Contains(stderr, "[line 1]") {
+				t.Fatalf("Expected the error to point at the loop, got %q", stderr)
+			}
+		})
+	}
+
+	// The limit is off by default
+	runSourceTests(t, []sourceTest{
+		{"No limit by default", `ধরি n = 0; যতক্ষণ (n < 100000) { n = n + 1; } n;`, 100000.0, ""},
+	})
+}
+
+func TestFloorMod(t *testing.T) {
+	runSourceTests(t, []sourceTest{
+		{"Operator truncates", `-5 % 3;`, -2.0, ""},
+		{"Native floors", `ভাগশেষ(-5, 3);`, 1.0, ""},
+		{"Operator with a negative divisor", `5 % -3;`, 2.0, ""},
+		{"Native with a negative divisor", `ভাগশেষ(5, -3);`, -1.0, ""},
+		{"Both negative agree", `[-5 % -3, ভাগশেষ(-5, -3)];`, []interface{}{-2.0, -2.0}, ""},
+		{"Both positive agree", `[7 % 3, ভাগশেষ(7, 3)];`, []interface{}{1.0, 1.0}, ""},
+		{"Exact multiple", `ভাগশেষ(-6, 3);`, 0.0, ""},
+		{"Fractional operands", `ভাগশেষ(-5.5, 2);`, 0.5, ""},
+		{"Division by zero", `ভাগশেষ(1, 0);`, nil, "floor_mod function cannot divide by zero"},
+	})
+}
+
+func TestWarnWritesToStderr(t *testing.T) {
+	utils.HadError = false
+	utils.HadRuntimeError = false
+
+	var stdout, stderr bytes.Buffer
+	i := NewInterpreter()
+	i.Stdout = &stdout
+	i.Stderr = &stderr
+
+	input := `দেখাও "ফলাফল", 1;
+	সতর্ক("সতর্কতা:", [1, "ক"], 0.1 + 0.2);
+	সতর্ক();
+	দেখাও "শেষ";`
+	capturedErr := CaptureStderr(func() {
+		tokens, _ := lexer.NewScanner([]rune(input)).ScanTokens()
+		stmts, _ := parser.NewParser(tokens).Parse()
+		i.Interpret(stmts, false)
+	})
+	if utils.HadError || utils.HadRuntimeError {
+		t.Fatalf("Unexpected error: %s", capturedErr)
+	}
+	if capturedErr != "" {
+		t.Fatalf("Expected nothing on os.Stderr, got %q", capturedErr)
+	}
+	if expected := "ফলাফল 1\nশেষ\n"; stdout.String() != expected {
+		t.Fatalf("Expected stdout %q, got %q", expected, stdout.String())
+	}
+	if expected := "সতর্কতা: [1, \"ক\"] 0.3\n\n"; stderr.String() != expected {
+		t.Fatalf("Expected stderr %q, got %q", expected, stderr.String())
+	}
+}
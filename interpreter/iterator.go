@@ -0,0 +1,100 @@
+package interpreter
+
+import "math"
+
+// Iterator produces the values of a sequence one at a time. Next reports
+// ok == false once the values run out.
+type Iterator interface {
+	Next() (value interface{}, ok bool)
+}
+
+// Iterable is a value a foreach loop can walk without building an array.
+// Every call to Iterate starts again from the first value.
+type Iterable interface {
+	Iterate() Iterator
+}
+
+// sliceIterator walks the items of an array, object or string.
+type sliceIterator struct {
+	items []interface{}
+	index int
+}
+
+func (s *sliceIterator) Next() (interface{}, bool) {
+	if s.index >= len(s.items) {
+		return nil, false
+	}
+	s.index++
+	return s.items[s.index-1], true
+}
+
+// iterate returns an iterator over the values a foreach loop visits.
+func iterate(value interface{}) (Iterator, bool) {
+	if iterable, ok := value.(Iterable); ok {
+		return iterable.Iterate(), true
+	}
+	items, ok := iterationItems(value)
+	if !ok {
+		return nil, false
+	}
+	return &sliceIterator{items: items}, true
+}
+
+// materialize returns the values of an Iterable as an array so it can be used
+// anywhere an array is expected. Any other value is returned unchanged.
+func materialize(value interface{}) interface{} {
+	iterable, ok := value.(Iterable)
+	if !ok {
+		return value
+	}
+	items := []interface{}{}
+	iterator := iterable.Iterate()
+	for item, ok := iterator.Next(); ok; item, ok = iterator.Next() {
+		items = append(items, item)
+	}
+	return items
+}
+
+// materializeAll materializes every value in place.
+func materializeAll(values []interface{}) []interface{} {
+	for index, value := range values {
+		values[index] = materialize(value)
+	}
+	return values
+}
+
+// Range is the lazy sequence of numbers পরিসর returns. It counts from Start
+// towards End, which it never reaches, in steps of Step.
+type Range struct {
+	Start, End, Step float64
+}
+
+// Len returns how many numbers the range produces.
+func (r *Range) Len() int {
+	count := math.Ceil((r.End - r.Start) / r.Step)
+	if count <= 0 || math.IsNaN(count) {
+		return 0
+	}
+	return int(count)
+}
+
+func (r *Range) Iterate() Iterator {
+	return &rangeIterator{rng: r, length: r.Len()}
+}
+
+// rangeIterator computes each value from its index rather than by repeated
+// addition, so fractional steps do not accumulate rounding errors.
+type rangeIterator struct {
+	rng    *Range
+	index  int
+	length int
+}
+
+func (r *rangeIterator) Next() (interface{}, bool) {
+	if r.index >= r.length {
+		return nil, false
+	}
+	value := r.rng.Start + float64(r.index)*r.rng.Step
+	r.index++
+	return value, true
+}
@@ -0,0 +1,91 @@
+package interpreter
+
+import "github.com/ah-naf/borno/utils"
+
+// Method tables map the names usable in `value.method(args)` to the native
+// that is called with the receiver as its first argument.
+var arrayMethods = utils.NormalizeNames(map[string]Callable{
+	"লেন":      NativeLenFn{},
+	"এড":       NativeAppendFn{},
+	"রিমুভ":    NativeRemoveFn{},
+	"ঢুকাও":    NativeInsertFn{},
+	"পরিষ্কার": NativeClearFn{},
+	"স্লাইস":   NativeSliceFn{},
+	"সংযুক্ত":  NativeConcatFn{},
+	"সমতল":     NativeFlattenFn{},
+	"জিপ":      NativeZipFn{},
+	"ম্যাপ":    NativeMapFn{},
+	"ফিল্টার":  NativeFilterFn{},
+	"প্রত্যেক_উপাদান": NativeForEachFn{},
+	"খুঁজে_পাও":       NativeFindElementFn{},
+	"প্রতিটি":         NativeEveryFn{},
+	"কিছু":            NativeSomeFn{},
+	"কপি":             NativeCopyFn{},
+	"খালি":            NativeEmptyFn{},
+	"অ_খালি":          NativeNotEmptyFn{},
+	"গুণফল":           NativeProductFn{},
+	"মধ্যমা":          NativeMedianFn{},
+	"মোড":             NativeModeFn{},
+	"সাজাও_দ্বারা":    NativeSortByFn{},
+	"বিপরীত_সাজাও":    NativeSortDescendingFn{},
+})
+
+var stringMethods = utils.NormalizeNames(map[string]Callable{
+	"লেন":         NativeLenFn{},
+	"খালি":        NativeEmptyFn{},
+	"অ_খালি":      NativeNotEmptyFn{},
+	"বড়হাতে":     NativeUpperFn{},
+	"ছোটহাতে":     NativeLowerFn{},
+	"প্রতিস্থাপন": NativeReplaceAllFn{},
+	"প্রতিস্থাপন_প্রথম": NativeReplaceFirstFn{},
+	"দিয়ে_শুরু":         NativeStartsWithFn{},
+	"দিয়ে_শেষ":          NativeEndsWithFn{},
+	"শুরু_ছাঁটো":        NativeTrimStartFn{},
+	"শেষ_ছাঁটো":         NativeTrimEndFn{},
+	"সংখ্যায়":           NativeToNumberFn{},
+	"ভাঙো":              NativeSplitFn{},
+	"রেজেক্স_ভাঙো":      NativeRegexSplitFn{},
+	"মেলে":              NativeMatchFn{},
+	"খুঁজো":             NativeFindFn{},
+	"সব_খুঁজো":          NativeFindAllFn{},
+	"টেমপ্লেট":          NativeTemplateFn{},
+})
+
+var objectMethods = utils.NormalizeNames(map[string]Callable{
+	"কি_রিমুভ":     NativeDeleteFn{},
+	"আছে_কি":       NativeHasFn{},
+	"অব্জেক্ট_কি":  NativeKeysFn{},
+	"অব্জেক্ট_মান": NativeValuesFn{},
+	"এন্ট্রি":      NativeEntriesFn{},
+	"মান_ম্যাপ":    NativeMapValuesFn{},
+	"কি_ম্যাপ":     NativeMapKeysFn{},
+	"একত্র":        NativeMergeFn{},
+	"নির্বাচন":     NativePickFn{},
+	"বাদ":          NativeOmitFn{},
+	"কপি":          NativeCopyFn{},
+	"লেন":          NativeLenFn{},
+	"খালি":         NativeEmptyFn{},
+	"অ_খালি":       NativeNotEmptyFn{},
+})
+
+// lookupMethod finds the built-in method called name for the receiver's type.
+// An object's own property always takes precedence over a method.
+func lookupMethod(receiver interface{}, name string) (Callable, bool) {
+	var methods map[string]Callable
+	switch value := receiver.(type) {
+	case []interface{}:
+		methods = arrayMethods
+	case string:
+		methods = stringMethods
+	case map[string]interface{}:
+		if _, exists := value[name]; exists {
+			return nil, false
+		}
+		methods = objectMethods
+	default:
+		return nil, false
+	}
+
+	method, ok := methods[name]
+	return method, ok
+}
@@ -1,11 +1,13 @@
 package interpreter
 
 import (
-	"bufio"
 	"fmt"
-	"os"
+	"io"
+	"reflect"
 	"strings"
 	"time"
+
+	"github.com/ah-naf/borno/utils"
 )
 
 type NativeClockFn struct{}
@@ -19,7 +21,64 @@ func (n NativeClockFn) Arity() int {
 }
 
 func (n NativeClockFn) String() string {
-	return "<native fn>"
+	return nativeSignature("clock", n)
+}
+
+// milliseconds converts a duration to fractional milliseconds.
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// NativeMonotonicFn defines the native `monotonic` function, which returns the
+// milliseconds since the interpreter was created. Unlike ক্লক it is not
+// affected by changes to the system clock, so differences between two
+// readings are reliable for timing.
+type NativeMonotonicFn struct{}
+
+func (n NativeMonotonicFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	return milliseconds(time.Since(i.started)), nil
+}
+
+func (n NativeMonotonicFn) Arity() int {
+	return 0
+}
+
+func (n NativeMonotonicFn) String() string {
+	return nativeSignature("monotonic", n)
+}
+
+// NativeMeasureFn defines the native `measure` function, which calls a
+// function without arguments once and returns how many milliseconds it took.
+type NativeMeasureFn struct{}
+
+func (n NativeMeasureFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	if len(arguments) != 1 {
+		return nil, nativeErrorf("measure function expects exactly 1 argument")
+	}
+	fn, ok := arguments[0].(Callable)
+	if !ok {
+		return nil, typeError("সময়_মাপো", "ফাংশনে", arguments[0])
+	}
+	if message := checkArity(fn, 0); message != "" {
+		return nil, &NativeError{Message: message}
+	}
+
+	start := time.Now()
+	if _, err := fn.Call(i, nil); err != nil {
+		return nil, err
+	}
+	if utils.HadRuntimeError {
+		return nil, nil
+	}
+	return milliseconds(time.Since(start)), nil
+}
+
+func (n NativeMeasureFn) Arity() int {
+	return 1
+}
+
+func (n NativeMeasureFn) String() string {
+	return nativeSignature("measure", n)
 }
 
 // NativeInputFn defines the native `input` function for the interpreter.
@@ -29,34 +88,18 @@ type NativeInputFn struct{}
 func (n NativeInputFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
 	// Check if there's an optional prompt argument
 	if len(arguments) > 1 {
-		return nil, fmt.Errorf("input function accepts at most 1 argument")
-	}
-
-	// If a prompt argument is provided, print it
-	if len(arguments) == 1 {
-		var prompt string
-		switch arg := arguments[0].(type) {
-		case string:
-			// Already a Go string
-			prompt = arg
-		case []rune:
-			// Convert rune slice to string
-			prompt = string(arg)
-		default:
-			return nil, fmt.Errorf("input function's argument must be a string or []rune")
-		}
-	
-		fmt.Print(prompt)
+		return nil, nativeErrorf("input function accepts at most 1 argument")
 	}
-
-	// Read the input from the user
-	reader := bufio.NewReader(os.Stdin)
-	input, err := reader.ReadString('\n')
-	if err != nil {
-		return nil, fmt.Errorf("failed to read input: %v", err)
+	if err := printPrompt(i, arguments, "ইনপুট"); err != nil {
+		return nil, err
 	}
 
-	// Trim the newline characters and return the input string
+	// Trim the newline characters and return the input string. At the end
+	// of input there is no line, so a reading loop can stop on nil
+	input, ok, err := readLine(i)
+	if err != nil || !ok {
+		return nil, err
+	}
 	return strings.TrimSpace(input), nil
 }
 
@@ -64,6 +107,433 @@ func (n NativeInputFn) Arity() int {
 	return -1 // Variable number of arguments: 0 or 1 (for prompt)
 }
 
+func (n NativeInputFn) MinArity() int {
+	return 0
+}
+
+func (n NativeInputFn) MaxArity() int {
+	return 1
+}
+
 func (n NativeInputFn) String() string {
-	return "<native fn input>"
+	return nativeSignature("input", n)
+}
+
+// NativeNumberInputFn defines the native `number_input` function, which reads
+// a line and parses it as a number. Like ইনপুট, it returns nil at the end of
+// input.
+type NativeNumberInputFn struct{}
+
+func (n NativeNumberInputFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	if len(arguments) > 1 {
+		return nil, nativeErrorf("number_input function accepts at most 1 argument")
+	}
+	if err := printPrompt(i, arguments, "সংখ্যা_ইনপুট"); err != nil {
+		return nil, err
+	}
+
+	input, ok, err := readLine(i)
+	if err != nil || !ok {
+		return nil, err
+	}
+	number, err := toNumber(strings.TrimSpace(input))
+	if err != nil {
+		return nil, nativeErrorf("%v", err)
+	}
+	return number, nil
+}
+
+func (n NativeNumberInputFn) Arity() int {
+	return -1
+}
+
+func (n NativeNumberInputFn) MinArity() int {
+	return 0
+}
+
+func (n NativeNumberInputFn) MaxArity() int {
+	return 1
+}
+
+func (n NativeNumberInputFn) String() string {
+	return nativeSignature("number_input", n)
+}
+
+// NativeReadAllFn defines the native `read_all` function, which returns
+// everything left on stdin up to EOF.
+type NativeReadAllFn struct{}
+
+func (n NativeReadAllFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	if len(arguments) != 0 {
+		return nil, nativeErrorf("read_all function takes no arguments")
+	}
+
+	content, err := io.ReadAll(i.stdin())
+	if err != nil {
+		return nil, nativeErrorf("failed to read input: %v", err)
+	}
+	return string(content), nil
+}
+
+func (n NativeReadAllFn) Arity() int {
+	return 0
+}
+
+func (n NativeReadAllFn) String() string {
+	return nativeSignature("read_all", n)
+}
+
+// NativeWarnFn defines the native `warn` function, which prints its arguments
+// like দেখাও but to the interpreter's stderr.
+type NativeWarnFn struct{}
+
+func (n NativeWarnFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	parts := make([]string, len(arguments))
+	for index, argument := range arguments {
+		parts[index] = i.printable(argument)
+	}
+	fmt.Fprintln(i.stderr(), strings.Join(parts, " "))
+	return nil, nil
+}
+
+func (n NativeWarnFn) Arity() int {
+	return -1
+}
+
+func (n NativeWarnFn) String() string {
+	return nativeSignature("warn", n)
+}
+
+// NativePrintWithFn defines the native `print_with` function. It prints its
+// values like দেখাও, joined by sep and followed by end instead of a single
+// space and a newline. A nil sep or end keeps that default.
+type NativePrintWithFn struct{}
+
+func (n NativePrintWithFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	if len(arguments) < 2 {
+		return nil, nativeErrorf("print_with function expects a separator and an ending")
+	}
+
+	sep, end := " ", "\n"
+	if arguments[0] != nil {
+		value, ok := arguments[0].(string)
+		if !ok {
+			return nil, argumentTypeError("দেখাও_সহ", 1, "স্ট্রিং বা nil", arguments[0])
+		}
+		sep = value
+	}
+	if arguments[1] != nil {
+		value, ok := arguments[1].(string)
+		if !ok {
+			return nil, argumentTypeError("দেখাও_সহ", 2, "স্ট্রিং বা nil", arguments[1])
+		}
+		end = value
+	}
+
+	parts := make([]string, len(arguments)-2)
+	for index, argument := range arguments[2:] {
+		parts[index] = i.printable(argument)
+	}
+	fmt.Fprint(i.stdout(), strings.Join(parts, sep)+end)
+	return nil, nil
+}
+
+func (n NativePrintWithFn) Arity() int {
+	return -1
+}
+
+func (n NativePrintWithFn) MinArity() int {
+	return 2
+}
+
+func (n NativePrintWithFn) MaxArity() int {
+	return -1
+}
+
+func (n NativePrintWithFn) String() string {
+	return nativeSignature("print_with", n)
+}
+
+// printPrompt writes the optional prompt argument of an input function.
+func printPrompt(i *Interpreter, arguments []interface{}, native string) error {
+	if len(arguments) == 0 {
+		return nil
+	}
+
+	prompt, ok := arguments[0].(string)
+	if !ok {
+		return typeError(native, "স্ট্রিংয়ে", arguments[0])
+	}
+	fmt.Fprint(i.stdout(), prompt)
+	return nil
+}
+
+// readLine reads one line from the interpreter's stdin. A final line without
+// a trailing newline is still returned. It reports ok == false at EOF with
+// nothing left to read, and an error only when reading fails.
+func readLine(i *Interpreter) (string, bool, error) {
+	input, err := i.stdin().ReadString('\n')
+	if err == io.EOF {
+		return input, input != "", nil
+	}
+	if err != nil {
+		return "", false, nativeErrorf("failed to read input: %v", err)
+	}
+	return input, true, nil
+}
+
+// NativeToNumberFn converts a number or numeric string to a number.
+type NativeToNumberFn struct{}
+
+func (n NativeToNumberFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	if len(arguments) != 1 {
+		return nil, nativeErrorf("number function expects exactly 1 argument")
+	}
+
+	switch value := arguments[0].(type) {
+	case int64, float64:
+		number, _ := toNumber(value)
+		return number, nil
+	case string:
+		number, err := toNumber(value)
+		if err != nil {
+			return nil, argumentValueError("সংখ্যায়", 1, "সংখ্যা লেখা স্ট্রিং", value)
+		}
+		return number, nil
+	default:
+		return nil, typeError("সংখ্যায়", "সংখ্যা ও স্ট্রিংয়ে", arguments[0])
+	}
+}
+
+func (n NativeToNumberFn) Arity() int {
+	return 1
+}
+
+func (n NativeToNumberFn) String() string {
+	return nativeSignature("number", n)
+}
+
+// NativeCopyFn defines the native `copy` function, which deep-copies arrays and objects.
+type NativeCopyFn struct{}
+
+func (n NativeCopyFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	if len(arguments) != 1 {
+		return nil, nativeErrorf("copy function expects exactly 1 argument")
+	}
+
+	return deepCopy(arguments[0], make(map[copyKey]interface{})), nil
+}
+
+func (n NativeCopyFn) Arity() int {
+	return 1
+}
+
+func (n NativeCopyFn) String() string {
+	return nativeSignature("copy", n)
+}
+
+// copyKey identifies an array or object that has already been copied, so
+// cyclic structures are copied once and keep their shape.
+type copyKey struct {
+	pointer uintptr
+	length  int
+}
+
+func deepCopy(value interface{}, copied map[copyKey]interface{}) interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		key := copyKey{pointer: reflect.ValueOf(v).Pointer(), length: len(v)}
+		if existing, ok := copied[key]; ok && len(v) > 0 {
+			return existing
+		}
+		result := make([]interface{}, len(v))
+		copied[key] = result
+		for index, element := range v {
+			result[index] = deepCopy(element, copied)
+		}
+		return result
+	case map[string]interface{}:
+		key := copyKey{pointer: reflect.ValueOf(v).Pointer()}
+		if existing, ok := copied[key]; ok {
+			return existing
+		}
+		result := make(map[string]interface{}, len(v))
+		copied[key] = result
+		for k, element := range v {
+			result[k] = deepCopy(element, copied)
+		}
+		return result
+	default:
+		return value
+	}
+}
+
+// NativePartialFn defines the native `partial` function, which binds leading
+// arguments to a callable.
+type NativePartialFn struct{}
+
+func (n NativePartialFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	if len(arguments) < 1 {
+		return nil, nativeErrorf("partial function expects at least 1 argument")
+	}
+
+	function, ok := arguments[0].(Callable)
+	if !ok {
+		return nil, typeError("আংশিক", "ফাংশনে", arguments[0])
+	}
+
+	bound := append([]interface{}(nil), arguments[1:]...)
+	if _, max := arityBounds(function); max != -1 && len(bound) > max {
+		return nil, nativeErrorf("cannot bind %d arguments to a function that takes %d", len(bound), max)
+	}
+
+	return &PartialFunction{Function: function, Bound: bound}, nil
+}
+
+func (n NativePartialFn) Arity() int {
+	return -1 // A callable followed by any number of bound arguments
+}
+
+func (n NativePartialFn) MinArity() int {
+	return 1
+}
+
+func (n NativePartialFn) MaxArity() int {
+	return -1
+}
+
+func (n NativePartialFn) String() string {
+	return nativeSignature("partial", n)
+}
+
+// PartialFunction is the callable returned by `partial`. It calls Function
+// with Bound prepended to the call-time arguments.
+type PartialFunction struct {
+	Function Callable
+	Bound    []interface{}
+}
+
+func (p *PartialFunction) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	combined := make([]interface{}, 0, len(p.Bound)+len(arguments))
+	combined = append(combined, p.Bound...)
+	combined = append(combined, arguments...)
+	return p.Function.Call(i, combined)
+}
+
+func (p *PartialFunction) Arity() int {
+	if p.Function.Arity() == -1 {
+		return -1
+	}
+	return p.Function.Arity() - len(p.Bound)
+}
+
+func (p *PartialFunction) MinArity() int {
+	min, _ := arityBounds(p.Function)
+	if min < len(p.Bound) {
+		return 0
+	}
+	return min - len(p.Bound)
+}
+
+func (p *PartialFunction) MaxArity() int {
+	_, max := arityBounds(p.Function)
+	if max == -1 {
+		return -1
+	}
+	return max - len(p.Bound)
+}
+
+func (p *PartialFunction) String() string {
+	return "<partial fn>"
+}
+
+// NativeErrorFn defines the native `error` function, which builds an error
+// object with a type and a message for নিক্ষেপ. The type defaults to "Error".
+type NativeErrorFn struct{}
+
+func (n NativeErrorFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	if len(arguments) < 1 || len(arguments) > 2 {
+		return nil, nativeErrorf("error function expects 1 or 2 arguments (optional type and message)")
+	}
+
+	strs := make([]string, len(arguments))
+	for index, argument := range arguments {
+		str, ok := argument.(string)
+		if !ok {
+			return nil, nativeErrorf("error function expects string arguments")
+		}
+		strs[index] = str
+	}
+
+	if len(strs) == 1 {
+		return newErrorObject("Error", strs[0]), nil
+	}
+	return newErrorObject(strs[0], strs[1]), nil
+}
+
+func (n NativeErrorFn) Arity() int {
+	return -1
+}
+
+func (n NativeErrorFn) MinArity() int {
+	return 1
+}
+
+func (n NativeErrorFn) MaxArity() int {
+	return 2
+}
+
+func (n NativeErrorFn) String() string {
+	return nativeSignature("error", n)
+}
+
+// newErrorObject builds the object thrown for runtime errors and by এরর.
+func newErrorObject(kind, message string) map[string]interface{} {
+	return map[string]interface{}{"type": kind, "message": message}
+}
+
+// describeThrown formats an uncaught thrown value, showing error objects as
+// "type: message".
+func describeThrown(value interface{}) string {
+	if object, ok := value.(map[string]interface{}); ok && len(object) == 2 {
+		kind, hasType := object["type"].(string)
+		message, hasMessage := object["message"].(string)
+		if hasType && hasMessage {
+			return kind + ": " + message
+		}
+	}
+	return stringify(value)
+}
+
+// NativeAssertEqualFn defines the native `assert_equal` function. It compares
+// arrays and objects deeply. In test mode a mismatch is reported with the line
+// of the assertion and counted instead of stopping the program.
+type NativeAssertEqualFn struct{}
+
+func (n NativeAssertEqualFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	if len(arguments) != 2 {
+		return nil, nativeErrorf("assert_equal function expects exactly 2 arguments (actual and expected)")
+	}
+
+	actual, expected := arguments[0], arguments[1]
+	if deepEqual(actual, expected, make(map[[2]uintptr]bool)) {
+		i.AssertionsPassed++
+		return nil, nil
+	}
+
+	message := fmt.Sprintf("assertion failed: expected %s but got %s", stringify(expected), stringify(actual))
+	if i.TestMode {
+		i.AssertionsFailed++
+		fmt.Fprintf(i.stderr(), "[line %d] %s\n", i.callLine, message)
+		return nil, nil
+	}
+	return nil, nativeErrorf("%s", message)
+}
+
+func (n NativeAssertEqualFn) Arity() int {
+	return 2
+}
+
+func (n NativeAssertEqualFn) String() string {
+	return nativeSignature("assert_equal", n)
 }
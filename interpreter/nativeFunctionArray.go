@@ -1,23 +1,23 @@
 package interpreter
 
-import "fmt"
+import (
+	"math"
+	"unicode/utf8"
+)
 
 type NativeLenFn struct{}
 
 // Call executes the native `len` function
 func (n NativeLenFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
 	if len(arguments) != 1 {
-		return nil, fmt.Errorf("len function expects exactly 1 argument")
+		return nil, nativeErrorf("len function expects exactly 1 argument")
 	}
 
-	// Check if the argument is a slice (array in our case)
-	array, ok := arguments[0].([]interface{})
+	length, ok := collectionLength(arguments[0])
 	if !ok {
-		return nil, fmt.Errorf("len function only works on arrays")
+		return nil, typeError("লেন", "অ্যারে, স্ট্রিং ও অব্জেক্টে", arguments[0])
 	}
-
-	// Return the length of the array
-	return len(array), nil
+	return length, nil
 }
 
 func (n NativeLenFn) Arity() int {
@@ -25,20 +25,80 @@ func (n NativeLenFn) Arity() int {
 }
 
 func (n NativeLenFn) String() string {
-	return "<native fn len>"
+	return nativeSignature("len", n)
+}
+
+// collectionLength returns the number of elements in an array, characters in
+// a string or keys in an object.
+func collectionLength(value interface{}) (int, bool) {
+	switch v := value.(type) {
+	case []interface{}:
+		return len(v), true
+	case string:
+		return utf8.RuneCountInString(v), true
+	case map[string]interface{}:
+		return len(v), true
+	}
+	return 0, false
+}
+
+// NativeEmptyFn defines the native `empty` function, which reports whether an
+// array, string or object has no elements. A string of spaces is not empty.
+type NativeEmptyFn struct{}
+
+func (n NativeEmptyFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	if len(arguments) != 1 {
+		return nil, nativeErrorf("empty function expects exactly 1 argument")
+	}
+	length, ok := collectionLength(arguments[0])
+	if !ok {
+		return nil, typeError("খালি", "অ্যারে, স্ট্রিং ও অব্জেক্টে", arguments[0])
+	}
+	return length == 0, nil
+}
+
+func (n NativeEmptyFn) Arity() int {
+	return 1
+}
+
+func (n NativeEmptyFn) String() string {
+	return nativeSignature("empty", n)
+}
+
+// NativeNotEmptyFn defines the native `not_empty` function, the negation of
+// `empty`.
+type NativeNotEmptyFn struct{}
+
+func (n NativeNotEmptyFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	if len(arguments) != 1 {
+		return nil, nativeErrorf("not_empty function expects exactly 1 argument")
+	}
+	length, ok := collectionLength(arguments[0])
+	if !ok {
+		return nil, typeError("অ_খালি", "অ্যারে, স্ট্রিং ও অব্জেক্টে", arguments[0])
+	}
+	return length != 0, nil
+}
+
+func (n NativeNotEmptyFn) Arity() int {
+	return 1
+}
+
+func (n NativeNotEmptyFn) String() string {
+	return nativeSignature("not_empty", n)
 }
 
 type NativeAppendFn struct{}
 
 func (n NativeAppendFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
 	if len(arguments) < 2 {
-		return nil, fmt.Errorf("append function expects at least 2 arguments (array and element(s))")
+		return nil, nativeErrorf("append function expects at least 2 arguments (array and element(s))")
 	}
 
 	// Ensure the first argument is an array
 	array, ok := arguments[0].([]interface{})
 	if !ok {
-		return nil, fmt.Errorf("append function only works on arrays")
+		return nil, typeError("এড", "অ্যারেতে", arguments[0])
 	}
 	// Append all other arguments to the array
 	array = append(array, arguments[1:]...)
@@ -50,32 +110,40 @@ func (n NativeAppendFn) Arity() int {
 	return -1 // Variable number of arguments (at least 2)
 }
 
+func (n NativeAppendFn) MinArity() int {
+	return 2
+}
+
+func (n NativeAppendFn) MaxArity() int {
+	return -1
+}
+
 func (n NativeAppendFn) String() string {
-	return "<native fn append>"
+	return nativeSignature("append", n)
 }
 
 type NativeRemoveFn struct{}
 
 func (n NativeRemoveFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
 	if len(arguments) != 2 {
-		return nil, fmt.Errorf("remove function expects exactly 2 arguments (array and index)")
+		return nil, nativeErrorf("remove function expects exactly 2 arguments (array and index)")
 	}
 
 	// Ensure the first argument is an array
 	array, ok := arguments[0].([]interface{})
 	if !ok {
-		return nil, fmt.Errorf("remove function only works on arrays")
+		return nil, typeError("রিমুভ", "অ্যারেতে", arguments[0])
 	}
 
 	// Ensure the second argument is an integer (index)
-	index, err := toInt64(arguments[1])
+	index, err := integerArgument("রিমুভ", arguments, 2)
 	if err != nil {
-		return nil, fmt.Errorf("array index must be an integer")
+		return nil, err
 	}
 
 	// Ensure the index is within bounds
 	if index < 0 || int(index) >= len(array) {
-		return nil, fmt.Errorf("array index out of bounds")
+		return nil, indexRangeError("রিমুভ", index)
 	}
 
 	// Remove the element at the specified index
@@ -89,5 +157,325 @@ func (n NativeRemoveFn) Arity() int {
 }
 
 func (n NativeRemoveFn) String() string {
-	return "<native fn remove>"
+	return nativeSignature("remove", n)
+}
+
+// indexRangeError reports an index that falls outside the array a native was
+// given.
+func indexRangeError(native string, index int64) error {
+	return nativeErrorf("%s-এর ইনডেক্স %d অ্যারের সীমার বাইরে", native, index)
+}
+
+// NativeInsertFn defines the native `insert` function, which returns a new
+// array with a value inserted before the given index.
+type NativeInsertFn struct{}
+
+func (n NativeInsertFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	if len(arguments) != 3 {
+		return nil, nativeErrorf("insert function expects exactly 3 arguments (array, index and value)")
+	}
+
+	array, ok := arguments[0].([]interface{})
+	if !ok {
+		return nil, typeError("ঢুকাও", "অ্যারেতে", arguments[0])
+	}
+
+	index, err := integerArgument("ঢুকাও", arguments, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	// Inserting at len(array) appends
+	if index < 0 || int(index) > len(array) {
+		return nil, indexRangeError("ঢুকাও", index)
+	}
+
+	result := make([]interface{}, 0, len(array)+1)
+	result = append(result, array[:index]...)
+	result = append(result, arguments[2])
+	result = append(result, array[index:]...)
+
+	return result, nil
+}
+
+func (n NativeInsertFn) Arity() int {
+	return 3 // Three arguments: array, index and value
+}
+
+func (n NativeInsertFn) String() string {
+	return nativeSignature("insert", n)
+}
+
+// NativeClearFn defines the native `clear` function, which returns an empty array.
+type NativeClearFn struct{}
+
+func (n NativeClearFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	if len(arguments) != 1 {
+		return nil, nativeErrorf("clear function expects exactly 1 argument")
+	}
+
+	if _, ok := arguments[0].([]interface{}); !ok {
+		return nil, typeError("পরিষ্কার", "অ্যারেতে", arguments[0])
+	}
+
+	return []interface{}{}, nil
+}
+
+func (n NativeClearFn) Arity() int {
+	return 1
+}
+
+func (n NativeClearFn) String() string {
+	return nativeSignature("clear", n)
+}
+
+// NativeSliceFn defines the native `slice` function, which copies the elements
+// from start up to (but not including) end into a new array. Negative indices
+// count from the end, out-of-range indices are clamped, and end defaults to
+// the length of the array.
+type NativeSliceFn struct{}
+
+func (n NativeSliceFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	if len(arguments) < 2 || len(arguments) > 3 {
+		return nil, nativeErrorf("slice function expects 2 or 3 arguments (array, start and optional end)")
+	}
+
+	array, ok := arguments[0].([]interface{})
+	if !ok {
+		return nil, typeError("স্লাইস", "অ্যারেতে", arguments[0])
+	}
+
+	start, err := integerArgument("স্লাইস", arguments, 2)
+	if err != nil {
+		return nil, err
+	}
+	end := int64(len(array))
+	if len(arguments) == 3 {
+		end, err = integerArgument("স্লাইস", arguments, 3)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	from, to := sliceIndex(start, len(array)), sliceIndex(end, len(array))
+	if from >= to {
+		return []interface{}{}, nil
+	}
+
+	result := make([]interface{}, to-from)
+	copy(result, array[from:to])
+	return result, nil
+}
+
+func (n NativeSliceFn) Arity() int {
+	return -1 // Two or three arguments: array, start and optional end
+}
+
+func (n NativeSliceFn) MinArity() int {
+	return 2
+}
+
+func (n NativeSliceFn) MaxArity() int {
+	return 3
+}
+
+func (n NativeSliceFn) String() string {
+	return nativeSignature("slice", n)
+}
+
+// sliceIndex resolves a possibly negative index against length, clamping it
+// to [0, length].
+func sliceIndex(index int64, length int) int {
+	if index < 0 {
+		index += int64(length)
+	}
+	if index < 0 {
+		return 0
+	}
+	if index > int64(length) {
+		return length
+	}
+	return int(index)
+}
+
+// NativeConcatFn defines the native `concat` function, which returns a new
+// array holding the elements of every argument in order.
+type NativeConcatFn struct{}
+
+func (n NativeConcatFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	if len(arguments) < 1 {
+		return nil, nativeErrorf("concat function expects at least 1 argument")
+	}
+
+	result := []interface{}{}
+	for index, argument := range arguments {
+		array, ok := argument.([]interface{})
+		if !ok {
+			return nil, nativeErrorf("সংযুক্ত-এর %d নম্বর আর্গুমেন্ট অ্যারে হতে হবে, পেয়েছি %s", index+1, typeName(argument))
+		}
+		result = append(result, array...)
+	}
+
+	return result, nil
+}
+
+func (n NativeConcatFn) Arity() int {
+	return -1 // Any number of arrays
+}
+
+func (n NativeConcatFn) MinArity() int {
+	return 1
+}
+
+func (n NativeConcatFn) MaxArity() int {
+	return -1
+}
+
+func (n NativeConcatFn) String() string {
+	return nativeSignature("concat", n)
+}
+
+// NativeFlattenFn defines the native `flatten` function, which flattens nested
+// arrays up to an optional depth (one level by default).
+type NativeFlattenFn struct{}
+
+func (n NativeFlattenFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	if len(arguments) < 1 || len(arguments) > 2 {
+		return nil, nativeErrorf("flatten function expects 1 or 2 arguments (array and optional depth)")
+	}
+
+	array, ok := arguments[0].([]interface{})
+	if !ok {
+		return nil, typeError("সমতল", "অ্যারেতে", arguments[0])
+	}
+
+	depth := int64(1)
+	if len(arguments) == 2 {
+		d, err := integerArgument("সমতল", arguments, 2)
+		if err != nil {
+			return nil, err
+		}
+		if d < 0 {
+			return nil, argumentValueError("সমতল", 2, "অঋণাত্মক পূর্ণসংখ্যা", arguments[1])
+		}
+		depth = d
+	}
+
+	return flatten(array, depth), nil
+}
+
+func flatten(array []interface{}, depth int64) []interface{} {
+	result := make([]interface{}, 0, len(array))
+	for _, element := range array {
+		if nested, ok := element.([]interface{}); ok && depth > 0 {
+			result = append(result, flatten(nested, depth-1)...)
+		} else {
+			result = append(result, element)
+		}
+	}
+	return result
+}
+
+func (n NativeFlattenFn) Arity() int {
+	return -1 // One or two arguments: array and optional depth
+}
+
+func (n NativeFlattenFn) MinArity() int {
+	return 1
+}
+
+func (n NativeFlattenFn) MaxArity() int {
+	return 2
+}
+
+func (n NativeFlattenFn) String() string {
+	return nativeSignature("flatten", n)
+}
+
+// NativeZipFn defines the native `zip` function, which pairs up the elements
+// of two arrays, stopping at the shorter one.
+type NativeZipFn struct{}
+
+func (n NativeZipFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	if len(arguments) != 2 {
+		return nil, nativeErrorf("zip function expects exactly 2 arguments")
+	}
+
+	first, ok := arguments[0].([]interface{})
+	if !ok {
+		return nil, typeError("জিপ", "অ্যারেতে", arguments[0])
+	}
+	second, ok := arguments[1].([]interface{})
+	if !ok {
+		return nil, typeError("জিপ", "অ্যারেতে", arguments[1])
+	}
+
+	length := len(first)
+	if len(second) < length {
+		length = len(second)
+	}
+
+	pairs := make([]interface{}, 0, length)
+	for index := 0; index < length; index++ {
+		pairs = append(pairs, []interface{}{first[index], second[index]})
+	}
+
+	return pairs, nil
+}
+
+func (n NativeZipFn) Arity() int {
+	return 2
+}
+
+func (n NativeZipFn) String() string {
+	return nativeSignature("zip", n)
+}
+
+// NativeRangeFn defines the native `range` function. পরিসর(end) counts from 0,
+// পরিসর(start, end) from start, and a third argument sets the step. The range
+// is lazy: a foreach loop walks it without building an array, and it only
+// becomes one when used where an array is expected.
+type NativeRangeFn struct{}
+
+func (n NativeRangeFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	if len(arguments) < 1 || len(arguments) > 3 {
+		return nil, nativeErrorf("range function expects 1 to 3 arguments")
+	}
+
+	bounds := make([]float64, len(arguments))
+	for index, argument := range arguments {
+		value, err := toNumber(argument)
+		if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+			return nil, nativeErrorf("range function expects finite numbers, got %s", stringifyElement(argument, defaultPrecision))
+		}
+		bounds[index] = value
+	}
+
+	r := &Range{Start: 0, End: bounds[0], Step: 1}
+	if len(bounds) > 1 {
+		r.Start, r.End = bounds[0], bounds[1]
+	}
+	if len(bounds) > 2 {
+		r.Step = bounds[2]
+	}
+	if r.Step == 0 {
+		return nil, nativeErrorf("range function's step cannot be zero")
+	}
+	return r, nil
+}
+
+func (n NativeRangeFn) Arity() int {
+	return -1
+}
+
+func (n NativeRangeFn) MinArity() int {
+	return 1
+}
+
+func (n NativeRangeFn) MaxArity() int {
+	return 3
+}
+
+func (n NativeRangeFn) String() string {
+	return nativeSignature("range", n)
 }
@@ -0,0 +1,337 @@
+package interpreter
+
+import (
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ah-naf/borno/utils"
+)
+
+// NativeMapFn defines the native `map` function, which returns a new array of
+// the callback's results.
+type NativeMapFn struct{}
+
+func (n NativeMapFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	array, fn, err := arrayAndCallback(arguments, "map", "ম্যাপ")
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]interface{}, 0, len(array))
+	err = eachElement(i, array, fn, func(element, result interface{}) bool {
+		results = append(results, result)
+		return true
+	})
+	return results, err
+}
+
+func (n NativeMapFn) Arity() int {
+	return 2
+}
+
+func (n NativeMapFn) String() string {
+	return nativeSignature("map", n)
+}
+
+// NativeFilterFn defines the native `filter` function, which returns the
+// elements the callback accepts.
+type NativeFilterFn struct{}
+
+func (n NativeFilterFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	array, fn, err := arrayAndCallback(arguments, "filter", "ফিল্টার")
+	if err != nil {
+		return nil, err
+	}
+
+	results := []interface{}{}
+	err = eachElement(i, array, fn, func(element, result interface{}) bool {
+		if isTruthy(result) {
+			results = append(results, element)
+		}
+		return true
+	})
+	return results, err
+}
+
+func (n NativeFilterFn) Arity() int {
+	return 2
+}
+
+func (n NativeFilterFn) String() string {
+	return nativeSignature("filter", n)
+}
+
+// NativeForEachFn defines the native `for_each` function, which calls the
+// callback for its side effects.
+type NativeForEachFn struct{}
+
+func (n NativeForEachFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	array, fn, err := arrayAndCallback(arguments, "for_each", "প্রত্যেক_উপাদান")
+	if err != nil {
+		return nil, err
+	}
+
+	err = eachElement(i, array, fn, func(element, result interface{}) bool {
+		return true
+	})
+	return nil, err
+}
+
+func (n NativeForEachFn) Arity() int {
+	return 2
+}
+
+func (n NativeForEachFn) String() string {
+	return nativeSignature("for_each", n)
+}
+
+// NativeFindElementFn defines the native `find_element` function, which
+// returns the first element the callback accepts, or nil.
+type NativeFindElementFn struct{}
+
+func (n NativeFindElementFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	array, fn, err := arrayAndCallback(arguments, "find_element", "খুঁজে_পাও")
+	if err != nil {
+		return nil, err
+	}
+
+	var found interface{}
+	err = eachElement(i, array, fn, func(element, result interface{}) bool {
+		if isTruthy(result) {
+			found = element
+			return false
+		}
+		return true
+	})
+	return found, err
+}
+
+func (n NativeFindElementFn) Arity() int {
+	return 2
+}
+
+func (n NativeFindElementFn) String() string {
+	return nativeSignature("find_element", n)
+}
+
+// NativeEveryFn defines the native `every` function. It stops at the first
+// element the callback rejects.
+type NativeEveryFn struct{}
+
+func (n NativeEveryFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	array, fn, err := arrayAndCallback(arguments, "every", "প্রতিটি")
+	if err != nil {
+		return nil, err
+	}
+
+	all := true
+	err = eachElement(i, array, fn, func(element, result interface{}) bool {
+		all = isTruthy(result)
+		return all
+	})
+	return all, err
+}
+
+func (n NativeEveryFn) Arity() int {
+	return 2
+}
+
+func (n NativeEveryFn) String() string {
+	return nativeSignature("every", n)
+}
+
+// NativeSomeFn defines the native `some` function. It stops at the first
+// element the callback accepts.
+type NativeSomeFn struct{}
+
+func (n NativeSomeFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	array, fn, err := arrayAndCallback(arguments, "some", "কিছু")
+	if err != nil {
+		return nil, err
+	}
+
+	matched := false
+	err = eachElement(i, array, fn, func(element, result interface{}) bool {
+		matched = isTruthy(result)
+		return !matched
+	})
+	return matched, err
+}
+
+func (n NativeSomeFn) Arity() int {
+	return 2
+}
+
+func (n NativeSomeFn) String() string {
+	return nativeSignature("some", n)
+}
+
+// NativeSortByFn defines the native `sort_by` function, which returns a copy
+// of an array sorted by the key the callback extracts from each element.
+type NativeSortByFn struct{}
+
+func (n NativeSortByFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	array, fn, err := arrayAndCallback(arguments, "sort_by", "সাজাও_দ্বারা")
+	if err != nil {
+		return nil, err
+	}
+	return sortByKey(i, array, fn, false, "sort_by")
+}
+
+func (n NativeSortByFn) Arity() int {
+	return 2
+}
+
+func (n NativeSortByFn) String() string {
+	return nativeSignature("sort_by", n)
+}
+
+// NativeSortDescendingFn defines the native `sort_descending` function. It
+// sorts a copy of an array from largest to smallest, by the elements
+// themselves or by the key an optional callback extracts.
+type NativeSortDescendingFn struct{}
+
+func (n NativeSortDescendingFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	if len(arguments) == 1 {
+		array, ok := arguments[0].([]interface{})
+		if !ok {
+			return nil, typeError("বিপরীত_সাজাও", "অ্যারেতে", arguments[0])
+		}
+		return sortByKey(i, array, nil, true, "sort_descending")
+	}
+	array, fn, err := arrayAndCallback(arguments, "sort_descending", "বিপরীত_সাজাও")
+	if err != nil {
+		return nil, err
+	}
+	return sortByKey(i, array, fn, true, "sort_descending")
+}
+
+func (n NativeSortDescendingFn) Arity() int {
+	return -1
+}
+
+func (n NativeSortDescendingFn) MinArity() int {
+	return 1
+}
+
+func (n NativeSortDescendingFn) MaxArity() int {
+	return 2
+}
+
+func (n NativeSortDescendingFn) String() string {
+	return nativeSignature("sort_descending", n)
+}
+
+// sortByKey returns a stably sorted copy of array. The callback runs once per
+// element and its results are kept, because sorting compares each key many
+// times. A nil fn sorts by the elements themselves. The keys must be all
+// numbers or all strings.
+func sortByKey(i *Interpreter, array []interface{}, fn Callable, descending bool, name string) (interface{}, error) {
+	keys := array
+	if fn != nil {
+		keys = make([]interface{}, 0, len(array))
+		err := eachElement(i, array, fn, func(element, result interface{}) bool {
+			keys = append(keys, result)
+			return true
+		})
+		if err != nil || utils.HadRuntimeError {
+			return nil, err
+		}
+	}
+
+	for index := range keys {
+		if _, ok := compareKeys(keys[index], keys[index]); !ok {
+			return nil, nativeErrorf("%s function can only sort by numbers or strings, got %s", name, stringifyElement(keys[index], defaultPrecision))
+		}
+		if _, ok := compareKeys(keys[0], keys[index]); !ok {
+			return nil, nativeErrorf("%s function cannot compare %s with %s", name, stringifyElement(keys[0], defaultPrecision), stringifyElement(keys[index], defaultPrecision))
+		}
+	}
+
+	order := make([]int, len(array))
+	for index := range order {
+		order[index] = index
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		result, _ := compareKeys(keys[order[a]], keys[order[b]])
+		if descending {
+			return result > 0
+		}
+		return result < 0
+	})
+
+	sorted := make([]interface{}, len(array))
+	for index, original := range order {
+		sorted[index] = array[original]
+	}
+	return sorted, nil
+}
+
+// compareKeys orders two sort keys, reporting ok == false when they are not
+// both numbers or both strings. Numbers compare exactly, even when one is a
+// big integer or a decimal.
+func compareKeys(a, b interface{}) (int, bool) {
+	if left, ok := a.(string); ok {
+		right, ok := b.(string)
+		if !ok {
+			return 0, false
+		}
+		return strings.Compare(left, right), true
+	}
+	var left, right *big.Rat
+	var ok bool
+	if left, ok = decimalOperand(a); !ok {
+		return 0, false
+	}
+	if right, ok = decimalOperand(b); !ok {
+		return 0, false
+	}
+	return left.Cmp(right), true
+}
+
+// arrayAndCallback validates the (array, function) arguments shared by the
+// higher-order natives.
+func arrayAndCallback(arguments []interface{}, name, native string) ([]interface{}, Callable, error) {
+	if len(arguments) != 2 {
+		return nil, nil, nativeErrorf("%s function expects exactly 2 arguments (array and function)", name)
+	}
+
+	array, ok := arguments[0].([]interface{})
+	if !ok {
+		return nil, nil, typeError(native, "অ্যারেতে", arguments[0])
+	}
+	fn, ok := arguments[1].(Callable)
+	if !ok {
+		return nil, nil, argumentTypeError(native, 2, "ফাংশন", arguments[1])
+	}
+	return array, fn, nil
+}
+
+// eachElement calls fn with each element of array in order, also passing the
+// element's index when fn takes exactly two arguments. visit receives the
+// callback's result and returns false to stop early. Iteration also stops
+// when the callback fails or raises a runtime error.
+func eachElement(i *Interpreter, array []interface{}, fn Callable, visit func(element, result interface{}) bool) error {
+	for index, element := range array {
+		arguments := []interface{}{element}
+		if fn.Arity() == 2 {
+			arguments = append(arguments, float64(index))
+		}
+		if message := checkArity(fn, len(arguments)); message != "" {
+			return &NativeError{Message: message}
+		}
+
+		result, err := fn.Call(i, arguments)
+		if err != nil {
+			return err
+		}
+		if utils.HadRuntimeError {
+			return nil
+		}
+		if !visit(element, result) {
+			return nil
+		}
+	}
+	return nil
+}
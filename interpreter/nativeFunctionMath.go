@@ -1,20 +1,22 @@
 package interpreter
 
 import (
-	"fmt"
 	"math"
+	"sort"
+	"strconv"
+	"strings"
 )
 
 type NativeAbsFn struct{}
 
 func (n NativeAbsFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
 	if len(arguments) != 1 {
-		return nil, fmt.Errorf("abs function expects exactly 1 argument")
+		return nil, nativeErrorf("abs function expects exactly 1 argument")
 	}
 
-	number, err := toNumber(arguments[0])
+	number, err := numberArgument("পরমমান", arguments, 1)
 	if err != nil {
-		return nil, fmt.Errorf("argument must be a number")
+		return nil, err
 	}
 
 	return math.Abs(number), nil
@@ -25,19 +27,19 @@ func (n NativeAbsFn) Arity() int {
 }
 
 func (n NativeAbsFn) String() string {
-	return "<native fn abs>"
+	return nativeSignature("abs", n)
 }
 
 type NativeSqrtFn struct{}
 
 func (n NativeSqrtFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
 	if len(arguments) != 1 {
-		return nil, fmt.Errorf("sqrt function expects exactly 1 argument")
+		return nil, nativeErrorf("sqrt function expects exactly 1 argument")
 	}
 
-	number, err := toNumber(arguments[0])
+	number, err := numberArgument("বর্গমূল", arguments, 1)
 	if err != nil {
-		return nil, fmt.Errorf("argument must be a number")
+		return nil, err
 	}
 
 	return math.Sqrt(number), nil
@@ -48,24 +50,24 @@ func (n NativeSqrtFn) Arity() int {
 }
 
 func (n NativeSqrtFn) String() string {
-	return "<native fn sqrt>"
+	return nativeSignature("sqrt", n)
 }
 
 type NativePowFn struct{}
 
 func (n NativePowFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
 	if len(arguments) != 2 {
-		return nil, fmt.Errorf("pow function expects exactly 2 arguments")
+		return nil, nativeErrorf("pow function expects exactly 2 arguments")
 	}
 
-	base, err := toNumber(arguments[0])
+	base, err := numberArgument("ঘাত", arguments, 1)
 	if err != nil {
-		return nil, fmt.Errorf("base must be a number")
+		return nil, err
 	}
 
-	exponent, err := toNumber(arguments[1])
+	exponent, err := numberArgument("ঘাত", arguments, 2)
 	if err != nil {
-		return nil, fmt.Errorf("exponent must be a number")
+		return nil, err
 	}
 
 	return math.Pow(base, exponent), nil
@@ -76,19 +78,19 @@ func (n NativePowFn) Arity() int {
 }
 
 func (n NativePowFn) String() string {
-	return "<native fn pow>"
+	return nativeSignature("pow", n)
 }
 
 type NativeSinFn struct{}
 
 func (n NativeSinFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
 	if len(arguments) != 1 {
-		return nil, fmt.Errorf("sin function expects exactly 1 argument")
+		return nil, nativeErrorf("sin function expects exactly 1 argument")
 	}
 
-	number, err := toNumber(arguments[0])
+	number, err := numberArgument("সাইন", arguments, 1)
 	if err != nil {
-		return nil, fmt.Errorf("argument must be a number")
+		return nil, err
 	}
 
 	return math.Sin(number), nil
@@ -99,19 +101,19 @@ func (n NativeSinFn) Arity() int {
 }
 
 func (n NativeSinFn) String() string {
-	return "<native fn sin>"
+	return nativeSignature("sin", n)
 }
 
 type NativeCosFn struct{}
 
 func (n NativeCosFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
 	if len(arguments) != 1 {
-		return nil, fmt.Errorf("cos function expects exactly 1 argument")
+		return nil, nativeErrorf("cos function expects exactly 1 argument")
 	}
 
-	number, err := toNumber(arguments[0])
+	number, err := numberArgument("কসাইন", arguments, 1)
 	if err != nil {
-		return nil, fmt.Errorf("argument must be a number")
+		return nil, err
 	}
 
 	return math.Cos(number), nil
@@ -122,19 +124,19 @@ func (n NativeCosFn) Arity() int {
 }
 
 func (n NativeCosFn) String() string {
-	return "<native fn cos>"
+	return nativeSignature("cos", n)
 }
 
 type NativeTanFn struct{}
 
 func (n NativeTanFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
 	if len(arguments) != 1 {
-		return nil, fmt.Errorf("tan function expects exactly 1 argument")
+		return nil, nativeErrorf("tan function expects exactly 1 argument")
 	}
 
-	number, err := toNumber(arguments[0])
+	number, err := numberArgument("ট্যান", arguments, 1)
 	if err != nil {
-		return nil, fmt.Errorf("argument must be a number")
+		return nil, err
 	}
 
 	return math.Tan(number), nil
@@ -145,7 +147,7 @@ func (n NativeTanFn) Arity() int {
 }
 
 func (n NativeTanFn) String() string {
-	return "<native fn tan>"
+	return nativeSignature("tan", n)
 }
 
 // NativeMinFn defines the native `min` function for the interpreter.
@@ -153,7 +155,7 @@ type NativeMinFn struct{}
 
 func (n NativeMinFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
 	if len(arguments) == 0 {
-		return nil, fmt.Errorf("min function expects at least 1 argument")
+		return nil, nativeErrorf("min function expects at least 1 argument")
 	}
 
 	// Flatten arguments if the first argument is an array
@@ -162,20 +164,20 @@ func (n NativeMinFn) Call(i *Interpreter, arguments []interface{}) (interface{},
 	}
 
 	if len(arguments) == 0 {
-		return nil, fmt.Errorf("min function expects a non-empty array or list of arguments")
+		return nil, nativeErrorf("min function expects a non-empty array or list of arguments")
 	}
 
 	// Convert the first argument to a number
 	minValue, err := toNumber(arguments[0])
 	if err != nil {
-		return nil, fmt.Errorf("all arguments must be numbers")
+		return nil, typeError("সর্বনিম্ন", "সংখ্যায়", arguments[0])
 	}
 
 	// Iterate over the remaining arguments
 	for _, arg := range arguments[1:] {
 		num, err := toNumber(arg)
 		if err != nil {
-			return nil, fmt.Errorf("all arguments must be numbers")
+			return nil, typeError("সর্বনিম্ন", "সংখ্যায়", arg)
 		}
 		if num < minValue {
 			minValue = num
@@ -189,8 +191,16 @@ func (n NativeMinFn) Arity() int {
 	return -1 // Variable number of arguments
 }
 
+func (n NativeMinFn) MinArity() int {
+	return 1
+}
+
+func (n NativeMinFn) MaxArity() int {
+	return -1
+}
+
 func (n NativeMinFn) String() string {
-	return "<native fn min>"
+	return nativeSignature("min", n)
 }
 
 // NativeMaxFn defines the native `max` function for the interpreter.
@@ -198,7 +208,7 @@ type NativeMaxFn struct{}
 
 func (n NativeMaxFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
 	if len(arguments) == 0 {
-		return nil, fmt.Errorf("max function expects at least 1 argument")
+		return nil, nativeErrorf("max function expects at least 1 argument")
 	}
 
 	// Flatten arguments if the first argument is an array
@@ -207,20 +217,20 @@ func (n NativeMaxFn) Call(i *Interpreter, arguments []interface{}) (interface{},
 	}
 
 	if len(arguments) == 0 {
-		return nil, fmt.Errorf("max function expects a non-empty array or list of arguments")
+		return nil, nativeErrorf("max function expects a non-empty array or list of arguments")
 	}
 
 	// Convert the first argument to a number
 	maxValue, err := toNumber(arguments[0])
 	if err != nil {
-		return nil, fmt.Errorf("all arguments must be numbers")
+		return nil, typeError("সর্বোচ্চ", "সংখ্যায়", arguments[0])
 	}
 
 	// Iterate over the remaining arguments
 	for _, arg := range arguments[1:] {
 		num, err := toNumber(arg)
 		if err != nil {
-			return nil, fmt.Errorf("all arguments must be numbers")
+			return nil, typeError("সর্বোচ্চ", "সংখ্যায়", arg)
 		}
 		if num > maxValue {
 			maxValue = num
@@ -234,20 +244,28 @@ func (n NativeMaxFn) Arity() int {
 	return -1 // Variable number of arguments
 }
 
+func (n NativeMaxFn) MinArity() int {
+	return 1
+}
+
+func (n NativeMaxFn) MaxArity() int {
+	return -1
+}
+
 func (n NativeMaxFn) String() string {
-	return "<native fn max>"
+	return nativeSignature("max", n)
 }
 
 type NativeRoundFn struct{}
 
 func (n NativeRoundFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
 	if len(arguments) != 1 {
-		return nil, fmt.Errorf("round function expects exactly 1 argument")
+		return nil, nativeErrorf("round function expects exactly 1 argument")
 	}
 
-	number, err := toNumber(arguments[0])
+	number, err := numberArgument("রাউন্ড", arguments, 1)
 	if err != nil {
-		return nil, fmt.Errorf("argument must be a number")
+		return nil, err
 	}
 
 	return math.Round(number), nil
@@ -258,5 +276,372 @@ func (n NativeRoundFn) Arity() int {
 }
 
 func (n NativeRoundFn) String() string {
-	return "<native fn round>"
+	return nativeSignature("round", n)
+}
+
+// NativeRoundToFn defines the native `round_to` function, which rounds a
+// number to a given number of decimal places.
+type NativeRoundToFn struct{}
+
+func (n NativeRoundToFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	if len(arguments) != 2 {
+		return nil, nativeErrorf("round_to function expects exactly 2 arguments (number and digits)")
+	}
+
+	number, err := numberArgument("গোল_করে", arguments, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	digits, err := integerArgument("গোল_করে", arguments, 2)
+	if err != nil {
+		return nil, err
+	}
+	if digits < 0 {
+		return nil, argumentValueError("গোল_করে", 2, "অঋণাত্মক পূর্ণসংখ্যা", arguments[1])
+	}
+
+	scale := math.Pow(10, float64(digits))
+	if math.IsInf(number*scale, 0) {
+		// Too many digits to scale; the number is already that precise
+		return number, nil
+	}
+	return math.Round(number*scale) / scale, nil
+}
+
+func (n NativeRoundToFn) Arity() int {
+	return 2
+}
+
+func (n NativeRoundToFn) String() string {
+	return nativeSignature("round_to", n)
+}
+
+// NativeClampFn defines the native `clamp` function, which limits a number to the range [lo, hi].
+type NativeClampFn struct{}
+
+func (n NativeClampFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	if len(arguments) != 3 {
+		return nil, nativeErrorf("clamp function expects exactly 3 arguments")
+	}
+
+	number, err := numberArgument("সীমাবদ্ধ", arguments, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	lo, err := numberArgument("সীমাবদ্ধ", arguments, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	hi, err := numberArgument("সীমাবদ্ধ", arguments, 3)
+	if err != nil {
+		return nil, err
+	}
+
+	if lo > hi {
+		return nil, nativeErrorf("সীমাবদ্ধ-এর নিচের সীমা উপরের সীমার চেয়ে বড় হতে পারে না")
+	}
+
+	return math.Min(math.Max(number, lo), hi), nil
+}
+
+func (n NativeClampFn) Arity() int {
+	return 3
+}
+
+func (n NativeClampFn) String() string {
+	return nativeSignature("clamp", n)
+}
+
+// NativeSignFn defines the native `sign` function, which returns -1, 0 or 1.
+type NativeSignFn struct{}
+
+func (n NativeSignFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	if len(arguments) != 1 {
+		return nil, nativeErrorf("sign function expects exactly 1 argument")
+	}
+
+	number, err := numberArgument("চিহ্ন", arguments, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case number > 0:
+		return 1.0, nil
+	case number < 0:
+		return -1.0, nil
+	default:
+		return 0.0, nil
+	}
+}
+
+func (n NativeSignFn) Arity() int {
+	return 1
+}
+
+func (n NativeSignFn) String() string {
+	return nativeSignature("sign", n)
+}
+
+// NativeHypotFn defines the native `hypot` function, which returns
+// sqrt(x*x + y*y) without overflowing for large x and y.
+type NativeHypotFn struct{}
+
+func (n NativeHypotFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	if len(arguments) != 2 {
+		return nil, nativeErrorf("hypot function expects exactly 2 arguments")
+	}
+
+	x, err := numberArgument("হাইপোট", arguments, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	y, err := numberArgument("হাইপোট", arguments, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	return math.Hypot(x, y), nil
+}
+
+func (n NativeHypotFn) Arity() int {
+	return 2
+}
+
+func (n NativeHypotFn) String() string {
+	return nativeSignature("hypot", n)
+}
+
+// NativeFloorModFn defines the native `floor_mod` function. Unlike the %
+// operator, which truncates like C and Go so the result takes the sign of the
+// dividend, it floors the quotient so the result takes the sign of the
+// divisor: -5 % 3 is -2 but floor_mod(-5, 3) is 1.
+type NativeFloorModFn struct{}
+
+func (n NativeFloorModFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	if len(arguments) != 2 {
+		return nil, nativeErrorf("floor_mod function expects exactly 2 arguments")
+	}
+
+	a, err := numberArgument("ভাগশেষ", arguments, 1)
+	if err != nil {
+		return nil, err
+	}
+	b, err := numberArgument("ভাগশেষ", arguments, 2)
+	if err != nil {
+		return nil, err
+	}
+	if b == 0 {
+		return nil, nativeErrorf("floor_mod function cannot divide by zero")
+	}
+
+	remainder := math.Mod(a, b)
+	if remainder != 0 && (remainder < 0) != (b < 0) {
+		remainder += b
+	}
+	return remainder, nil
+}
+
+func (n NativeFloorModFn) Arity() int {
+	return 2
+}
+
+func (n NativeFloorModFn) String() string {
+	return nativeSignature("floor_mod", n)
+}
+
+// numericArray converts an array argument to floats, rejecting anything that
+// is not a number.
+func numericArray(arguments []interface{}, name, native string) ([]float64, error) {
+	if len(arguments) != 1 {
+		return nil, nativeErrorf("%s function expects exactly 1 argument", name)
+	}
+	array, ok := arguments[0].([]interface{})
+	if !ok {
+		return nil, typeError(native, "অ্যারেতে", arguments[0])
+	}
+	numbers := make([]float64, len(array))
+	for index, element := range array {
+		if !isNumber(element) {
+			return nil, nativeErrorf("%s শুধু সংখ্যার অ্যারেতে কাজ করে, %d নম্বর ইনডেক্সে পেয়েছি %s", native, index, typeName(element))
+		}
+		numbers[index], _ = toNumber(element)
+	}
+	return numbers, nil
+}
+
+// NativeProductFn multiplies the numbers in an array. The product of an empty
+// array is 1.
+type NativeProductFn struct{}
+
+func (n NativeProductFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	numbers, err := numericArray(arguments, "product", "গুণফল")
+	if err != nil {
+		return nil, err
+	}
+	product := 1.0
+	for _, number := range numbers {
+		product *= number
+	}
+	return product, nil
+}
+
+func (n NativeProductFn) Arity() int {
+	return 1
+}
+
+func (n NativeProductFn) String() string {
+	return nativeSignature("product", n)
+}
+
+// NativeMedianFn returns the middle value of a sorted copy of an array, or the
+// average of the two middle values when the length is even.
+type NativeMedianFn struct{}
+
+func (n NativeMedianFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	numbers, err := numericArray(arguments, "median", "মধ্যমা")
+	if err != nil {
+		return nil, err
+	}
+	if len(numbers) == 0 {
+		return nil, nativeErrorf("median function expects a non-empty array")
+	}
+	sort.Float64s(numbers)
+	middle := len(numbers) / 2
+	if len(numbers)%2 == 0 {
+		return (numbers[middle-1] + numbers[middle]) / 2, nil
+	}
+	return numbers[middle], nil
+}
+
+func (n NativeMedianFn) Arity() int {
+	return 1
+}
+
+func (n NativeMedianFn) String() string {
+	return nativeSignature("median", n)
+}
+
+// NativeModeFn returns the most frequent number in an array. When several
+// numbers are equally frequent, the one that appears first wins.
+type NativeModeFn struct{}
+
+func (n NativeModeFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	numbers, err := numericArray(arguments, "mode", "মোড")
+	if err != nil {
+		return nil, err
+	}
+	if len(numbers) == 0 {
+		return nil, nativeErrorf("mode function expects a non-empty array")
+	}
+	counts := make(map[float64]int, len(numbers))
+	for _, number := range numbers {
+		counts[number]++
+	}
+	// Only a strictly higher count replaces the mode, so ties keep the value
+	// that appears first
+	mode, best := numbers[0], 0
+	for _, number := range numbers {
+		if counts[number] > best {
+			mode, best = number, counts[number]
+		}
+	}
+	return mode, nil
+}
+
+func (n NativeModeFn) Arity() int {
+	return 1
+}
+
+func (n NativeModeFn) String() string {
+	return nativeSignature("mode", n)
+}
+
+// formatInBase implements হেক্স and বাইনারি, which write a non-negative
+// integer in the given base.
+func formatInBase(arguments []interface{}, name, native string, base int) (interface{}, error) {
+	if len(arguments) != 1 {
+		return nil, nativeErrorf("%s function expects exactly 1 argument", name)
+	}
+
+	number, err := integerArgument(native, arguments, 1)
+	if err != nil {
+		return nil, err
+	}
+	if number < 0 {
+		return nil, argumentValueError(native, 1, "অঋণাত্মক পূর্ণসংখ্যা", arguments[0])
+	}
+
+	return strconv.FormatInt(number, base), nil
+}
+
+type NativeHexFn struct{}
+
+func (n NativeHexFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	return formatInBase(arguments, "hex", "হেক্স", 16)
+}
+
+func (n NativeHexFn) Arity() int {
+	return 1
+}
+
+func (n NativeHexFn) String() string {
+	return nativeSignature("hex", n)
+}
+
+type NativeBinaryFn struct{}
+
+func (n NativeBinaryFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	return formatInBase(arguments, "binary", "বাইনারি", 2)
+}
+
+func (n NativeBinaryFn) Arity() int {
+	return 1
+}
+
+func (n NativeBinaryFn) String() string {
+	return nativeSignature("binary", n)
+}
+
+// NativeParseBaseFn parses a string of digits in a base from 2 to 36 into an
+// integer. Letters stand for the digits above 9 in either case.
+type NativeParseBaseFn struct{}
+
+func (n NativeParseBaseFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	if len(arguments) != 2 {
+		return nil, nativeErrorf("parse_base function expects exactly 2 arguments")
+	}
+
+	text, ok := arguments[0].(string)
+	if !ok {
+		return nil, typeError("পার্স_বেস", "স্ট্রিংয়ে", arguments[0])
+	}
+	base, err := integerArgument("পার্স_বেস", arguments, 2)
+	if err != nil {
+		return nil, err
+	}
+	if base < 2 || base > 36 {
+		return nil, argumentValueError("পার্স_বেস", 2, "2 থেকে 36-এর মধ্যে", arguments[1])
+	}
+
+	number, err := strconv.ParseInt(strings.TrimSpace(text), int(base), 64)
+	if err != nil {
+		if numErr, ok := err.(*strconv.NumError); ok && numErr.Err == strconv.ErrRange {
+			return nil, nativeErrorf("parse_base function's value %q does not fit in 64 bits", text)
+		}
+		return nil, nativeErrorf("parse_base function cannot read %q in base %d", text, base)
+	}
+	return number, nil
+}
+
+func (n NativeParseBaseFn) Arity() int {
+	return 2
+}
+
+func (n NativeParseBaseFn) String() string {
+	return nativeSignature("parse_base", n)
 }
@@ -1,37 +1,39 @@
 package interpreter
 
-import "fmt"
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ah-naf/borno/utils"
+)
 
 type NativeDeleteFn struct{}
 
 func (n NativeDeleteFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
 	// Ensure we have exactly 2 arguments: the object and the key
 	if len(arguments) != 2 {
-		return nil, fmt.Errorf("delete function expects exactly 2 arguments (object and key)")
+		return nil, nativeErrorf("delete function expects exactly 2 arguments (object and key)")
 	}
 
 	// Ensure the first argument is an object (map)
 	object, ok := arguments[0].(map[string]interface{})
 	if !ok {
-		return nil, fmt.Errorf("delete function only works on objects")
+		return nil, typeError("কি_রিমুভ", "অব্জেক্টে", arguments[0])
 	}
 
-	// Ensure the second argument is a string (key)
-	var key string
-	switch v := arguments[1].(type) {
-	case string:
-		key = v
-	case []rune:
-		key = string(v) // Convert []rune to string
-	default:
-		return nil, fmt.Errorf("delete function expects the second argument to be a string key")
+	// Normalize the key the same way bracket access does, so o[1] and
+	// কি_রিমুভ(o, 1) name the same property
+	key, err := objectKey(arguments[1])
+	if err != nil {
+		return nil, nativeErrorf("কি_রিমুভ: %v", err)
 	}
 
 	// Remove the key if it exists
 	if _, exists := object[key]; exists {
 		delete(object, key)
 	} else {
-		return nil, fmt.Errorf("key '%s' not found in object", key)
+		return nil, nativeErrorf("key '%s' not found in object", key)
 	}
 
 	return object, nil
@@ -42,19 +44,49 @@ func (n NativeDeleteFn) Arity() int {
 }
 
 func (n NativeDeleteFn) String() string {
-	return "<native fn delete>"
+	return nativeSignature("delete", n)
+}
+
+// NativeHasFn defines the native `has` function, which reports whether an
+// object has a key without the error that reading a missing property raises.
+type NativeHasFn struct{}
+
+func (n NativeHasFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	if len(arguments) != 2 {
+		return nil, nativeErrorf("has function expects exactly 2 arguments (object and key)")
+	}
+
+	object, ok := arguments[0].(map[string]interface{})
+	if !ok {
+		return nil, typeError("আছে_কি", "অব্জেক্টে", arguments[0])
+	}
+
+	key, err := objectKey(arguments[1])
+	if err != nil {
+		return nil, nativeErrorf("আছে_কি: %v", err)
+	}
+	_, exists := object[key]
+	return exists, nil
+}
+
+func (n NativeHasFn) Arity() int {
+	return 2
+}
+
+func (n NativeHasFn) String() string {
+	return nativeSignature("has", n)
 }
 
 type NativeKeysFn struct{}
 
 func (n NativeKeysFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
 	if len(arguments) != 1 {
-		return nil, fmt.Errorf("keys function expects exactly 1 argument")
+		return nil, nativeErrorf("keys function expects exactly 1 argument")
 	}
 
 	object, ok := arguments[0].(map[string]interface{})
 	if !ok {
-		return nil, fmt.Errorf("keys function only works on objects")
+		return nil, typeError("অব্জেক্ট_কি", "অব্জেক্টে", arguments[0])
 	}
 
 	keys := make([]interface{}, 0, len(object))
@@ -70,19 +102,19 @@ func (n NativeKeysFn) Arity() int {
 }
 
 func (n NativeKeysFn) String() string {
-	return "<native fn keys>"
+	return nativeSignature("keys", n)
 }
 
 type NativeValuesFn struct{}
 
 func (n NativeValuesFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
 	if len(arguments) != 1 {
-		return nil, fmt.Errorf("values function expects exactly 1 argument")
+		return nil, nativeErrorf("values function expects exactly 1 argument")
 	}
 
 	object, ok := arguments[0].(map[string]interface{})
 	if !ok {
-		return nil, fmt.Errorf("values function only works on objects")
+		return nil, typeError("অব্জেক্ট_মান", "অব্জেক্টে", arguments[0])
 	}
 
 	values := make([]interface{}, 0, len(object))
@@ -98,5 +130,448 @@ func (n NativeValuesFn) Arity() int {
 }
 
 func (n NativeValuesFn) String() string {
-	return "<native fn values>"
+	return nativeSignature("values", n)
+}
+
+// NativeEntriesFn defines the native `entries` function, which returns an
+// object's [key, value] pairs. Objects do not remember insertion order, so the
+// pairs are sorted by key to keep the result stable.
+type NativeEntriesFn struct{}
+
+func (n NativeEntriesFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	if len(arguments) != 1 {
+		return nil, nativeErrorf("entries function expects exactly 1 argument")
+	}
+
+	object, ok := arguments[0].(map[string]interface{})
+	if !ok {
+		return nil, typeError("এন্ট্রি", "অব্জেক্টে", arguments[0])
+	}
+
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	entries := make([]interface{}, 0, len(keys))
+	for _, key := range keys {
+		entries = append(entries, []interface{}{key, object[key]})
+	}
+
+	return entries, nil
+}
+
+func (n NativeEntriesFn) Arity() int {
+	return 1
+}
+
+func (n NativeEntriesFn) String() string {
+	return nativeSignature("entries", n)
+}
+
+// NativeFromEntriesFn defines the native `from_entries` function, which builds
+// an object from an array of [key, value] pairs.
+type NativeFromEntriesFn struct{}
+
+func (n NativeFromEntriesFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	if len(arguments) != 1 {
+		return nil, nativeErrorf("from_entries function expects exactly 1 argument")
+	}
+
+	entries, ok := arguments[0].([]interface{})
+	if !ok {
+		return nil, typeError("এন্ট্রি_থেকে", "অ্যারেতে", arguments[0])
+	}
+
+	object := make(map[string]interface{}, len(entries))
+	for index, entry := range entries {
+		pair, ok := entry.([]interface{})
+		if !ok || len(pair) != 2 {
+			return nil, nativeErrorf("এন্ট্রি_থেকে-এর %d নম্বর এন্ট্রি [কী, মান] জোড়া হতে হবে, পেয়েছি %s", index, stringifyElement(entry, defaultPrecision))
+		}
+
+		key, ok := pair[0].(string)
+		if !ok {
+			return nil, nativeErrorf("এন্ট্রি_থেকে-এর %d নম্বর এন্ট্রির কী স্ট্রিং হতে হবে, পেয়েছি %s", index, typeName(pair[0]))
+		}
+
+		object[utils.NormalizeName(key)] = pair[1]
+	}
+
+	return object, nil
+}
+
+func (n NativeFromEntriesFn) Arity() int {
+	return 1
+}
+
+func (n NativeFromEntriesFn) String() string {
+	return nativeSignature("from_entries", n)
+}
+
+// pathSegments splits a dotted path such as "a.b.2.c" into its keys.
+func pathSegments(value interface{}, name, native string) ([]string, error) {
+	path, ok := value.(string)
+	if !ok {
+		return nil, argumentTypeError(native, 2, "স্ট্রিং", value)
+	}
+	segments := strings.Split(path, ".")
+	for _, segment := range segments {
+		if segment == "" {
+			return nil, nativeErrorf("%s function got a malformed path %q", name, path)
+		}
+	}
+	return segments, nil
+}
+
+// pathIndex converts a path segment to an array index.
+func pathIndex(segment string, name string) (int, error) {
+	index, err := strconv.Atoi(utils.ConvertBanglaDigitsToASCII(segment))
+	if err != nil {
+		return 0, nativeErrorf("%s function cannot index an array with %q", name, segment)
+	}
+	return index, nil
+}
+
+// NativeNestedGetFn defines the native `nested_get` function, which follows
+// a dotted path through objects and arrays. It returns nil as soon as a step
+// is missing.
+type NativeNestedGetFn struct{}
+
+func (n NativeNestedGetFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	if len(arguments) != 2 {
+		return nil, nativeErrorf("nested_get function expects exactly 2 arguments (root and path)")
+	}
+	segments, err := pathSegments(arguments[1], "nested_get", "নেস্টেড_পাও")
+	if err != nil {
+		return nil, err
+	}
+
+	current := arguments[0]
+	for _, segment := range segments {
+		switch container := current.(type) {
+		case map[string]interface{}:
+			current = container[segment]
+		case []interface{}:
+			index, err := pathIndex(segment, "nested_get")
+			if err != nil {
+				return nil, err
+			}
+			if index < 0 || index >= len(container) {
+				return nil, nil
+			}
+			current = container[index]
+		default:
+			return nil, nil
+		}
+	}
+	return current, nil
+}
+
+func (n NativeNestedGetFn) Arity() int {
+	return 2
+}
+
+func (n NativeNestedGetFn) String() string {
+	return nativeSignature("nested_get", n)
+}
+
+// NativeNestedSetFn defines the native `nested_set` function, which stores a
+// value at a dotted path, creating objects for missing intermediate keys.
+type NativeNestedSetFn struct{}
+
+func (n NativeNestedSetFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	if len(arguments) != 3 {
+		return nil, nativeErrorf("nested_set function expects exactly 3 arguments (root, path and value)")
+	}
+	segments, err := pathSegments(arguments[1], "nested_set", "নেস্টেড_সেট")
+	if err != nil {
+		return nil, err
+	}
+
+	current := arguments[0]
+	for step, segment := range segments {
+		last := step == len(segments)-1
+		switch container := current.(type) {
+		case map[string]interface{}:
+			if last {
+				container[segment] = arguments[2]
+				return arguments[2], nil
+			}
+			next := container[segment]
+			if next == nil {
+				next = make(map[string]interface{})
+				container[segment] = next
+			}
+			current = next
+		case []interface{}:
+			index, err := pathIndex(segment, "nested_set")
+			if err != nil {
+				return nil, err
+			}
+			if index < 0 || index >= len(container) {
+				return nil, nativeErrorf("nested_set function got index %d out of bounds for an array of length %d", index, len(container))
+			}
+			if last {
+				container[index] = arguments[2]
+				return arguments[2], nil
+			}
+			if container[index] == nil {
+				container[index] = make(map[string]interface{})
+			}
+			current = container[index]
+		default:
+			return nil, nativeErrorf("nested_set function cannot set %q inside %s", segment, stringify(current))
+		}
+	}
+	return arguments[2], nil
+}
+
+func (n NativeNestedSetFn) Arity() int {
+	return 3
+}
+
+func (n NativeNestedSetFn) String() string {
+	return nativeSignature("nested_set", n)
+}
+
+// NativeMapValuesFn defines the native `map_values` function, which returns a
+// new object with the same keys and each value replaced by the callback's
+// result. A callback with two parameters also receives the key.
+type NativeMapValuesFn struct{}
+
+func (n NativeMapValuesFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	object, fn, err := objectAndCallback(arguments, "map_values", "মান_ম্যাপ")
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]interface{}, len(object))
+	err = eachProperty(i, object, fn, false, func(key string, value interface{}) error {
+		result[key] = value
+		return nil
+	})
+	return result, err
+}
+
+func (n NativeMapValuesFn) Arity() int {
+	return 2
+}
+
+func (n NativeMapValuesFn) String() string {
+	return nativeSignature("map_values", n)
+}
+
+// NativeMapKeysFn defines the native `map_keys` function, which returns a new
+// object with each key replaced by the callback's result and the values kept.
+// A callback with two parameters also receives the value. Two keys mapping to
+// the same new key is an error rather than silently dropping a value.
+type NativeMapKeysFn struct{}
+
+func (n NativeMapKeysFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	object, fn, err := objectAndCallback(arguments, "map_keys", "কি_ম্যাপ")
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]interface{}, len(object))
+	err = eachProperty(i, object, fn, true, func(key string, newKey interface{}) error {
+		name, err := objectKey(newKey)
+		if err != nil {
+			return nativeErrorf("কি_ম্যাপ: %v", err)
+		}
+		if _, exists := result[name]; exists {
+			return nativeErrorf("map_keys function produced the key %q more than once", name)
+		}
+		result[name] = object[key]
+		return nil
+	})
+	return result, err
+}
+
+func (n NativeMapKeysFn) Arity() int {
+	return 2
+}
+
+func (n NativeMapKeysFn) String() string {
+	return nativeSignature("map_keys", n)
+}
+
+// objectAndCallback validates the (object, function) arguments shared by the
+// object transform natives.
+func objectAndCallback(arguments []interface{}, name, native string) (map[string]interface{}, Callable, error) {
+	if len(arguments) != 2 {
+		return nil, nil, nativeErrorf("%s function expects exactly 2 arguments (object and function)", name)
+	}
+
+	object, ok := arguments[0].(map[string]interface{})
+	if !ok {
+		return nil, nil, typeError(native, "অব্জেক্টে", arguments[0])
+	}
+	fn, ok := arguments[1].(Callable)
+	if !ok {
+		return nil, nil, argumentTypeError(native, 2, "ফাংশন", arguments[1])
+	}
+	return object, fn, nil
+}
+
+// eachProperty calls fn once per property of object in key order, like
+// eachElement does for arrays. fn receives the value, or the key when byKey
+// is set, and also the other half of the pair when it takes two arguments.
+// visit receives the key and the callback's result.
+func eachProperty(i *Interpreter, object map[string]interface{}, fn Callable, byKey bool, visit func(key string, result interface{}) error) error {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		first, second := object[key], interface{}(key)
+		if byKey {
+			first, second = second, first
+		}
+		arguments := []interface{}{first}
+		if fn.Arity() == 2 {
+			arguments = append(arguments, second)
+		}
+		if message := checkArity(fn, len(arguments)); message != "" {
+			return &NativeError{Message: message}
+		}
+
+		result, err := fn.Call(i, arguments)
+		if err != nil {
+			return err
+		}
+		if utils.HadRuntimeError {
+			return nil
+		}
+		if err := visit(key, result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NativeMergeFn defines the native `merge` function, which copies the
+// properties of its objects into a new one from left to right, so later
+// objects win on shared keys. Objects do not remember insertion order, so the
+// result prints its keys sorted like any other object.
+type NativeMergeFn struct{}
+
+func (n NativeMergeFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	if len(arguments) < 1 {
+		return nil, nativeErrorf("merge function expects at least 1 object")
+	}
+
+	merged := make(map[string]interface{})
+	for index, argument := range arguments {
+		object, ok := argument.(map[string]interface{})
+		if !ok {
+			return nil, nativeErrorf("একত্র শুধু অব্জেক্টে কাজ করে, পেয়েছি %s (আর্গুমেন্ট %d)", typeName(argument), index+1)
+		}
+		for key, value := range object {
+			merged[key] = value
+		}
+	}
+	return merged, nil
+}
+
+func (n NativeMergeFn) Arity() int {
+	return -1
+}
+
+func (n NativeMergeFn) MinArity() int {
+	return 1
+}
+
+func (n NativeMergeFn) MaxArity() int {
+	return -1
+}
+
+func (n NativeMergeFn) String() string {
+	return nativeSignature("merge", n)
+}
+
+// NativePickFn defines the native `pick` function, which returns a new object
+// with only the listed keys. Keys the object does not have are skipped.
+type NativePickFn struct{}
+
+func (n NativePickFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	object, keys, err := objectAndKeys(arguments, "pick", "নির্বাচন")
+	if err != nil {
+		return nil, err
+	}
+
+	picked := make(map[string]interface{}, len(keys))
+	for _, key := range keys {
+		if value, exists := object[key]; exists {
+			picked[key] = value
+		}
+	}
+	return picked, nil
+}
+
+func (n NativePickFn) Arity() int {
+	return 2
+}
+
+func (n NativePickFn) String() string {
+	return nativeSignature("pick", n)
+}
+
+// NativeOmitFn defines the native `omit` function, which returns a new object
+// without the listed keys.
+type NativeOmitFn struct{}
+
+func (n NativeOmitFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	object, keys, err := objectAndKeys(arguments, "omit", "বাদ")
+	if err != nil {
+		return nil, err
+	}
+
+	rest := make(map[string]interface{}, len(object))
+	for key, value := range object {
+		rest[key] = value
+	}
+	for _, key := range keys {
+		delete(rest, key)
+	}
+	return rest, nil
+}
+
+func (n NativeOmitFn) Arity() int {
+	return 2
+}
+
+func (n NativeOmitFn) String() string {
+	return nativeSignature("omit", n)
+}
+
+// objectAndKeys validates the (object, keys) arguments of pick and omit. The
+// keys are normalized like bracket access, so 1 and "1" name the same key.
+func objectAndKeys(arguments []interface{}, name, native string) (map[string]interface{}, []string, error) {
+	if len(arguments) != 2 {
+		return nil, nil, nativeErrorf("%s function expects exactly 2 arguments (object and keys)", name)
+	}
+
+	object, ok := arguments[0].(map[string]interface{})
+	if !ok {
+		return nil, nil, typeError(native, "অব্জেক্টে", arguments[0])
+	}
+	list, ok := arguments[1].([]interface{})
+	if !ok {
+		return nil, nil, argumentTypeError(native, 2, "অ্যারে", arguments[1])
+	}
+
+	keys := make([]string, len(list))
+	for index, value := range list {
+		key, err := objectKey(value)
+		if err != nil {
+			return nil, nil, nativeErrorf("%s: %v", native, err)
+		}
+		keys[index] = key
+	}
+	return object, keys, nil
 }
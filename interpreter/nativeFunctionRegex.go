@@ -0,0 +1,140 @@
+package interpreter
+
+import (
+	"regexp"
+)
+
+// compilePattern compiles the regular expression a native takes as its second
+// argument, reporting an invalid pattern as an error of the named function. Compiled patterns are cached on
+// the interpreter so a pattern used in a loop is only compiled once.
+func (i *Interpreter) compilePattern(value interface{}, name, native string) (*regexp.Regexp, error) {
+	pattern, ok := value.(string)
+	if !ok {
+		return nil, argumentTypeError(native, 2, "স্ট্রিং", value)
+	}
+	if re, ok := i.regexCache[pattern]; ok {
+		return re, nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, nativeErrorf("%s function got an invalid pattern: %v", name, err)
+	}
+	if i.regexCache == nil {
+		i.regexCache = make(map[string]*regexp.Regexp)
+	}
+	i.regexCache[pattern] = re
+	return re, nil
+}
+
+// NativeRegexSplitFn defines the native `regex_split` function, which splits a
+// string around every match of a regular expression.
+type NativeRegexSplitFn struct{}
+
+func (n NativeRegexSplitFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	if len(arguments) != 2 {
+		return nil, nativeErrorf("regex_split function expects exactly 2 arguments (string and pattern)")
+	}
+
+	str, ok := arguments[0].(string)
+	if !ok {
+		return nil, typeError("রেজেক্স_ভাঙো", "স্ট্রিংয়ে", arguments[0])
+	}
+	re, err := i.compilePattern(arguments[1], "regex_split", "রেজেক্স_ভাঙো")
+	if err != nil {
+		return nil, err
+	}
+
+	return stringsToArray(re.Split(str, -1)), nil
+}
+
+func (n NativeRegexSplitFn) Arity() int {
+	return 2
+}
+
+func (n NativeRegexSplitFn) String() string {
+	return nativeSignature("regex_split", n)
+}
+
+// NativeMatchFn defines the native `matches` function, which reports whether
+// a regular expression matches anywhere in a string.
+type NativeMatchFn struct{}
+
+func (n NativeMatchFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	str, re, err := regexArguments(i, arguments, "matches", "মেলে")
+	if err != nil {
+		return nil, err
+	}
+	return re.MatchString(str), nil
+}
+
+func (n NativeMatchFn) Arity() int {
+	return 2
+}
+
+func (n NativeMatchFn) String() string {
+	return nativeSignature("matches", n)
+}
+
+// NativeFindFn defines the native `find` function, which returns the first
+// match of a regular expression or nil when there is none.
+type NativeFindFn struct{}
+
+func (n NativeFindFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	str, re, err := regexArguments(i, arguments, "find", "খুঁজো")
+	if err != nil {
+		return nil, err
+	}
+
+	location := re.FindStringIndex(str)
+	if location == nil {
+		return nil, nil
+	}
+	return str[location[0]:location[1]], nil
+}
+
+func (n NativeFindFn) Arity() int {
+	return 2
+}
+
+func (n NativeFindFn) String() string {
+	return nativeSignature("find", n)
+}
+
+// NativeFindAllFn defines the native `find_all` function, which returns every
+// non-overlapping match of a regular expression.
+type NativeFindAllFn struct{}
+
+func (n NativeFindAllFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	str, re, err := regexArguments(i, arguments, "find_all", "সব_খুঁজো")
+	if err != nil {
+		return nil, err
+	}
+	return stringsToArray(re.FindAllString(str, -1)), nil
+}
+
+func (n NativeFindAllFn) Arity() int {
+	return 2
+}
+
+func (n NativeFindAllFn) String() string {
+	return nativeSignature("find_all", n)
+}
+
+// regexArguments validates the (string, pattern) arguments shared by the
+// regex natives.
+func regexArguments(i *Interpreter, arguments []interface{}, name, native string) (string, *regexp.Regexp, error) {
+	if len(arguments) != 2 {
+		return "", nil, nativeErrorf("%s function expects exactly 2 arguments (string and pattern)", name)
+	}
+
+	str, ok := arguments[0].(string)
+	if !ok {
+		return "", nil, typeError(native, "স্ট্রিংয়ে", arguments[0])
+	}
+	re, err := i.compilePattern(arguments[1], name, native)
+	if err != nil {
+		return "", nil, err
+	}
+	return str, re, nil
+}
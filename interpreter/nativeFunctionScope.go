@@ -0,0 +1,98 @@
+package interpreter
+
+import (
+	"github.com/ah-naf/borno/environment"
+	"github.com/ah-naf/borno/utils"
+)
+
+// NativeGlobalGetFn defines the native `global_get` function, which reads a
+// top-level variable even when a local of the same name shadows it.
+type NativeGlobalGetFn struct{}
+
+func (n NativeGlobalGetFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	if len(arguments) != 1 {
+		return nil, nativeErrorf("global_get function expects exactly 1 argument")
+	}
+
+	name, ok := arguments[0].(string)
+	if !ok {
+		return nil, typeError("গ্লোবাল_পাও", "স্ট্রিংয়ে", arguments[0])
+	}
+	name = utils.NormalizeName(name)
+
+	value, err := i.environment.Get(name)
+	if err != nil {
+		return nil, nativeErrorf("undefined global variable '%s'", name)
+	}
+	return value, nil
+}
+
+func (n NativeGlobalGetFn) Arity() int {
+	return 1
+}
+
+func (n NativeGlobalGetFn) String() string {
+	return nativeSignature("global_get", n)
+}
+
+// NativeGlobalSetFn defines the native `global_set` function, which assigns
+// an existing top-level variable even when a local of the same name shadows it.
+type NativeGlobalSetFn struct{}
+
+func (n NativeGlobalSetFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	if len(arguments) != 2 {
+		return nil, nativeErrorf("global_set function expects exactly 2 arguments (name and value)")
+	}
+
+	name, ok := arguments[0].(string)
+	if !ok {
+		return nil, argumentTypeError("গ্লোবাল_সেট", 1, "স্ট্রিং", arguments[0])
+	}
+	name = utils.NormalizeName(name)
+
+	// Natives live in the parent scope and are not reassignable from here
+	if _, err := i.environment.GetInCurrentScope(name); err != nil {
+		return nil, nativeErrorf("undefined global variable '%s'", name)
+	}
+	i.environment.Define(name, arguments[1])
+	return arguments[1], nil
+}
+
+func (n NativeGlobalSetFn) Arity() int {
+	return 2
+}
+
+func (n NativeGlobalSetFn) String() string {
+	return nativeSignature("global_set", n)
+}
+
+// NativeDefinedFn defines the native `defined` function, which reports
+// whether a variable is visible from the scope it is called in.
+type NativeDefinedFn struct{}
+
+func (n NativeDefinedFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	return n.CallInScope(i, i.environment, arguments)
+}
+
+func (n NativeDefinedFn) CallInScope(i *Interpreter, env *environment.Environment, arguments []interface{}) (interface{}, error) {
+	if len(arguments) != 1 {
+		return nil, nativeErrorf("defined function expects exactly 1 argument")
+	}
+
+	name, ok := arguments[0].(string)
+	if !ok {
+		return nil, typeError("সংজ্ঞায়িত", "স্ট্রিংয়ে", arguments[0])
+	}
+	name = utils.NormalizeName(name)
+
+	_, err := env.Get(name)
+	return err == nil, nil
+}
+
+func (n NativeDefinedFn) Arity() int {
+	return 1
+}
+
+func (n NativeDefinedFn) String() string {
+	return nativeSignature("defined", n)
+}
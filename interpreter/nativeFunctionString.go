@@ -0,0 +1,350 @@
+package interpreter
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/ah-naf/borno/utils"
+)
+
+// NativeReplaceAllFn defines the native `replace_all` function.
+type NativeReplaceAllFn struct{}
+
+func (n NativeReplaceAllFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	return replace(arguments, -1, "replace_all", "প্রতিস্থাপন")
+}
+
+func (n NativeReplaceAllFn) Arity() int {
+	return 3
+}
+
+func (n NativeReplaceAllFn) String() string {
+	return nativeSignature("replace_all", n)
+}
+
+// NativeReplaceFirstFn defines the native `replace_first` function.
+type NativeReplaceFirstFn struct{}
+
+func (n NativeReplaceFirstFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	return replace(arguments, 1, "replace_first", "প্রতিস্থাপন_প্রথম")
+}
+
+func (n NativeReplaceFirstFn) Arity() int {
+	return 3
+}
+
+func (n NativeReplaceFirstFn) String() string {
+	return nativeSignature("replace_first", n)
+}
+
+// replace substitutes up to count occurrences of old with new (-1 for all).
+func replace(arguments []interface{}, count int, name, native string) (interface{}, error) {
+	if len(arguments) != 3 {
+		return nil, nativeErrorf("%s function expects exactly 3 arguments (string, old and new)", name)
+	}
+
+	str, ok := arguments[0].(string)
+	if !ok {
+		return nil, typeError(native, "স্ট্রিংয়ে", arguments[0])
+	}
+	old, ok := arguments[1].(string)
+	if !ok {
+		return nil, argumentTypeError(native, 2, "স্ট্রিং", arguments[1])
+	}
+	replacement, ok := arguments[2].(string)
+	if !ok {
+		return nil, argumentTypeError(native, 3, "স্ট্রিং", arguments[2])
+	}
+
+	// An empty pattern would match between every character
+	if old == "" {
+		return nil, nativeErrorf("%s function cannot replace an empty substring", name)
+	}
+
+	return strings.Replace(str, old, replacement, count), nil
+}
+
+// NativeStartsWithFn defines the native `starts_with` function.
+type NativeStartsWithFn struct{}
+
+func (n NativeStartsWithFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	str, prefix, err := stringPair(arguments, "starts_with", "দিয়ে_শুরু")
+	if err != nil {
+		return nil, err
+	}
+	return strings.HasPrefix(str, prefix), nil
+}
+
+func (n NativeStartsWithFn) Arity() int {
+	return 2
+}
+
+func (n NativeStartsWithFn) String() string {
+	return nativeSignature("starts_with", n)
+}
+
+// NativeEndsWithFn defines the native `ends_with` function.
+type NativeEndsWithFn struct{}
+
+func (n NativeEndsWithFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	str, suffix, err := stringPair(arguments, "ends_with", "দিয়ে_শেষ")
+	if err != nil {
+		return nil, err
+	}
+	return strings.HasSuffix(str, suffix), nil
+}
+
+func (n NativeEndsWithFn) Arity() int {
+	return 2
+}
+
+func (n NativeEndsWithFn) String() string {
+	return nativeSignature("ends_with", n)
+}
+
+// stringPair validates the (string, pattern) arguments shared by the prefix and suffix checks.
+func stringPair(arguments []interface{}, name, native string) (string, string, error) {
+	if len(arguments) != 2 {
+		return "", "", nativeErrorf("%s function expects exactly 2 arguments", name)
+	}
+
+	str, ok := arguments[0].(string)
+	if !ok {
+		return "", "", typeError(native, "স্ট্রিংয়ে", arguments[0])
+	}
+	pattern, ok := arguments[1].(string)
+	if !ok {
+		return "", "", argumentTypeError(native, 2, "স্ট্রিং", arguments[1])
+	}
+	return str, pattern, nil
+}
+
+// NativeTrimStartFn defines the native `trim_start` function.
+type NativeTrimStartFn struct{}
+
+func (n NativeTrimStartFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	if len(arguments) != 1 {
+		return nil, nativeErrorf("trim_start function expects exactly 1 argument")
+	}
+
+	str, ok := arguments[0].(string)
+	if !ok {
+		return nil, typeError("শুরু_ছাঁটো", "স্ট্রিংয়ে", arguments[0])
+	}
+	return strings.TrimLeftFunc(str, unicode.IsSpace), nil
+}
+
+func (n NativeTrimStartFn) Arity() int {
+	return 1
+}
+
+func (n NativeTrimStartFn) String() string {
+	return nativeSignature("trim_start", n)
+}
+
+// NativeTrimEndFn defines the native `trim_end` function.
+type NativeTrimEndFn struct{}
+
+func (n NativeTrimEndFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	if len(arguments) != 1 {
+		return nil, nativeErrorf("trim_end function expects exactly 1 argument")
+	}
+
+	str, ok := arguments[0].(string)
+	if !ok {
+		return nil, typeError("শেষ_ছাঁটো", "স্ট্রিংয়ে", arguments[0])
+	}
+	return strings.TrimRightFunc(str, unicode.IsSpace), nil
+}
+
+func (n NativeTrimEndFn) Arity() int {
+	return 1
+}
+
+func (n NativeTrimEndFn) String() string {
+	return nativeSignature("trim_end", n)
+}
+
+// NativeUpperFn defines the native `upper` function.
+type NativeUpperFn struct{}
+
+func (n NativeUpperFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	if len(arguments) != 1 {
+		return nil, nativeErrorf("upper function expects exactly 1 argument")
+	}
+
+	str, ok := arguments[0].(string)
+	if !ok {
+		return nil, typeError("বড়হাতে", "স্ট্রিংয়ে", arguments[0])
+	}
+	return strings.ToUpper(str), nil
+}
+
+func (n NativeUpperFn) Arity() int {
+	return 1
+}
+
+func (n NativeUpperFn) String() string {
+	return nativeSignature("upper", n)
+}
+
+// NativeLowerFn defines the native `lower` function.
+type NativeLowerFn struct{}
+
+func (n NativeLowerFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	if len(arguments) != 1 {
+		return nil, nativeErrorf("lower function expects exactly 1 argument")
+	}
+
+	str, ok := arguments[0].(string)
+	if !ok {
+		return nil, typeError("ছোটহাতে", "স্ট্রিংয়ে", arguments[0])
+	}
+	return strings.ToLower(str), nil
+}
+
+func (n NativeLowerFn) Arity() int {
+	return 1
+}
+
+func (n NativeLowerFn) String() string {
+	return nativeSignature("lower", n)
+}
+
+// NativeSplitFn defines the native `split` function. An optional limit caps
+// the number of pieces, leaving the rest of the string in the last one.
+type NativeSplitFn struct{}
+
+func (n NativeSplitFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	if len(arguments) < 2 || len(arguments) > 3 {
+		return nil, nativeErrorf("split function expects 2 or 3 arguments (string, delimiter and optional limit)")
+	}
+
+	str, ok := arguments[0].(string)
+	if !ok {
+		return nil, typeError("ভাঙো", "স্ট্রিংয়ে", arguments[0])
+	}
+	delimiter, ok := arguments[1].(string)
+	if !ok {
+		return nil, argumentTypeError("ভাঙো", 2, "স্ট্রিং", arguments[1])
+	}
+
+	limit := int64(-1)
+	if len(arguments) == 3 {
+		var err error
+		limit, err = integerArgument("ভাঙো", arguments, 3)
+		if err != nil {
+			return nil, err
+		}
+		if limit < 1 {
+			return nil, argumentValueError("ভাঙো", 3, "ধনাত্মক পূর্ণসংখ্যা", arguments[2])
+		}
+	}
+
+	return stringsToArray(strings.SplitN(str, delimiter, int(limit))), nil
+}
+
+func (n NativeSplitFn) Arity() int {
+	return -1
+}
+
+func (n NativeSplitFn) MinArity() int {
+	return 2
+}
+
+func (n NativeSplitFn) MaxArity() int {
+	return 3
+}
+
+func (n NativeSplitFn) String() string {
+	return nativeSignature("split", n)
+}
+
+// stringsToArray converts Go strings to a Borno array.
+func stringsToArray(parts []string) []interface{} {
+	array := make([]interface{}, len(parts))
+	for index, part := range parts {
+		array[index] = part
+	}
+	return array
+}
+
+// NativeTemplateFn defines the native `template` function, which fills each
+// {key} placeholder in a string with that key's value from an object. Unknown
+// placeholders are left as they are unless the optional third argument is
+// সত্য, which makes them an error. {{ and }} stand for literal braces.
+type NativeTemplateFn struct{}
+
+func (n NativeTemplateFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	if len(arguments) < 2 || len(arguments) > 3 {
+		return nil, nativeErrorf("template function expects 2 or 3 arguments (string, object and optional strict flag)")
+	}
+
+	str, ok := arguments[0].(string)
+	if !ok {
+		return nil, typeError("টেমপ্লেট", "স্ট্রিংয়ে", arguments[0])
+	}
+	values, ok := arguments[1].(map[string]interface{})
+	if !ok {
+		return nil, argumentTypeError("টেমপ্লেট", 2, "অব্জেক্ট", arguments[1])
+	}
+	strict := false
+	if len(arguments) == 3 {
+		strict, ok = arguments[2].(bool)
+		if !ok {
+			return nil, argumentTypeError("টেমপ্লেট", 3, "বুলিয়ান", arguments[2])
+		}
+	}
+
+	var out strings.Builder
+	for index := 0; index < len(str); {
+		rest := str[index:]
+		switch {
+		case strings.HasPrefix(rest, "{{"):
+			out.WriteByte('{')
+			index += 2
+		case strings.HasPrefix(rest, "}}"):
+			out.WriteByte('}')
+			index += 2
+		case rest[0] == '{':
+			end := strings.IndexByte(rest, '}')
+			if end < 0 {
+				if strict {
+					return nil, nativeErrorf("template function found an unclosed placeholder")
+				}
+				out.WriteString(rest)
+				index = len(str)
+				continue
+			}
+			key := utils.NormalizeName(rest[1:end])
+			if value, exists := values[key]; exists {
+				out.WriteString(stringify(value))
+			} else if strict {
+				return nil, nativeErrorf("template function has no value for {%s}", key)
+			} else {
+				out.WriteString(rest[:end+1])
+			}
+			index += end + 1
+		default:
+			out.WriteByte(rest[0])
+			index++
+		}
+	}
+	return out.String(), nil
+}
+
+func (n NativeTemplateFn) Arity() int {
+	return -1
+}
+
+func (n NativeTemplateFn) MinArity() int {
+	return 2
+}
+
+func (n NativeTemplateFn) MaxArity() int {
+	return 3
+}
+
+func (n NativeTemplateFn) String() string {
+	return nativeSignature("template", n)
+}
@@ -0,0 +1,121 @@
+package interpreter
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ah-naf/borno/token"
+)
+
+// typeName returns the Bangla name of a runtime value's type. ধরন returns it,
+// and type errors use it to say what they got instead of what they expected.
+func typeName(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "nil"
+	case float64, int64, int, *big.Int, *big.Rat:
+		return "সংখ্যা"
+	case string:
+		return "স্ট্রিং"
+	case bool:
+		return "বুলিয়ান"
+	case []interface{}:
+		return "অ্যারে"
+	case map[string]interface{}:
+		return "অব্জেক্ট"
+	case *Range:
+		return "পরিসর"
+	case Callable:
+		return "ফাংশন"
+	}
+	return "অজানা"
+}
+
+// typeError reports that a native only works on the kinds of value listed in
+// accepts, such as "অ্যারেতে", and names the type of the value it got. native
+// is the Bangla name programs call the native by.
+func typeError(native, accepts string, value interface{}) error {
+	return nativeErrorf("%s শুধু %s কাজ করে, পেয়েছি %s", native, accepts, typeName(value))
+}
+
+// argumentOrdinals names the argument positions argument errors report.
+var argumentOrdinals = map[int]string{1: "প্রথম", 2: "দ্বিতীয়", 3: "তৃতীয়"}
+
+// argumentTypeError reports that the argument at a 1-based position is not the
+// type a native wants. Natives whose first argument is the value they work on
+// report it with typeError instead.
+func argumentTypeError(native string, position int, want string, value interface{}) error {
+	return nativeErrorf("%s-এর %s আর্গুমেন্ট %s হতে হবে, পেয়েছি %s", native, argumentOrdinals[position], want, typeName(value))
+}
+
+// argumentValueError reports that an argument has the right type but a value
+// the native cannot use, and shows the value it got.
+func argumentValueError(native string, position int, want string, value interface{}) error {
+	return nativeErrorf("%s-এর %s আর্গুমেন্ট %s হতে হবে, পেয়েছি %s", native, argumentOrdinals[position], want, stringifyElement(value, defaultPrecision))
+}
+
+// numberArgument converts the argument at a 1-based position to a number,
+// naming its type when it is not one.
+func numberArgument(native string, arguments []interface{}, position int) (float64, error) {
+	value := arguments[position-1]
+	number, err := toNumber(value)
+	if err != nil {
+		if position == 1 {
+			return 0, typeError(native, "সংখ্যায়", value)
+		}
+		return 0, argumentTypeError(native, position, "সংখ্যা", value)
+	}
+	return number, nil
+}
+
+// integerArgument converts the argument at a 1-based position to an integer.
+// A value that is not a number at all is a type error; a number with a
+// fractional part, or one too large for 64 bits, is shown as it is.
+func integerArgument(native string, arguments []interface{}, position int) (int64, error) {
+	value := arguments[position-1]
+	integer, err := toInt64(value)
+	if err == nil {
+		return integer, nil
+	}
+	if _, err := toNumber(value); err == nil {
+		return 0, argumentValueError(native, position, "পূর্ণসংখ্যা", value)
+	}
+	if position == 1 {
+		return 0, typeError(native, "পূর্ণসংখ্যায়", value)
+	}
+	return 0, argumentTypeError(native, position, "পূর্ণসংখ্যা", value)
+}
+
+// operandError describes an operand an operator cannot use. side is "বাম" or
+// "ডান" for the operands of a binary operator and empty for the only operand
+// of a unary one.
+func operandError(operator token.Token, side, want string, value interface{}) string {
+	operand := "অপারেন্ড"
+	if side != "" {
+		operand = side + " অপারেন্ড"
+	}
+	return fmt.Sprintf("'%s' অপারেটরের %s %s হতে হবে, পেয়েছি %s", operator.Lexeme, operand, want, typeName(value))
+}
+
+// NativeTypeFn defines the native `type` function, which names the type of
+// its argument in Bangla.
+type NativeTypeFn struct{}
+
+func (n NativeTypeFn) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	if len(arguments) != 1 {
+		return nil, nativeErrorf("type function expects exactly 1 argument")
+	}
+	return typeName(arguments[0]), nil
+}
+
+// TakesLazyArguments lets ধরন see a range as a range rather than the array
+// it would be built out into.
+func (n NativeTypeFn) TakesLazyArguments() {}
+
+func (n NativeTypeFn) Arity() int {
+	return 1
+}
+
+func (n NativeTypeFn) String() string {
+	return nativeSignature("type", n)
+}
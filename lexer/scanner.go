@@ -1,6 +1,9 @@
 package lexer
 
 import (
+	"fmt"
+	"math/big"
+	"sort"
 	"strconv"
 	"unicode"
 
@@ -8,7 +11,7 @@ import (
 	"github.com/ah-naf/borno/utils"
 )
 
-var keywords = map[string]token.TokenType{
+var keywords = utils.NormalizeNames(map[string]token.TokenType{
 	"ফাংশন":      token.FUN,
 	"ধরি":        token.VAR,
 	"ফর":         token.FOR,
@@ -22,18 +25,107 @@ var keywords = map[string]token.TokenType{
 	"ফেরত":       token.RETURN,
 	"থামো":       token.BREAK,
 	"চালিয়ে_যাও": token.CONTINUE,
+	"সুইচ":       token.SWITCH,
+	"ক্ষেত্রে":   token.CASE,
+	"নইলে":       token.DEFAULT,
+	"প্রত্যেক":   token.FOREACH,
+	"ইন":         token.IN,
+	"চেষ্টা":     token.TRY,
+	"ধরো":        token.CATCH,
+	"অবশেষে":     token.FINALLY,
+	"নিক্ষেপ":    token.THROW,
 
 	// Logical operators in Bangla
 	"এবং": token.LOGICAL_AND,
 	"বা":  token.LOGICAL_OR,
+})
+
+// keywordLexemes maps each keyword token type back to the Bangla spelling
+// that scans as it. The English aliases in englishKeywords are never the
+// canonical spelling, so tools always print the Bangla keyword.
+var keywordLexemes = func() map[token.TokenType]string {
+	lexemes := make(map[token.TokenType]string, len(keywords))
+	for lexeme, tokenType := range keywords {
+		// Should two spellings ever share a type, pick one deterministically
+		if existing, ok := lexemes[tokenType]; !ok || lexeme < existing {
+			lexemes[tokenType] = lexeme
+		}
+	}
+	return lexemes
+}()
+
+// KeywordLexeme returns the canonical spelling of a keyword token type, such
+// as "ফাংশন" for token.FUN. It reports false for types that are not keywords.
+func KeywordLexeme(tokenType token.TokenType) (string, bool) {
+	lexeme, ok := keywordLexemes[tokenType]
+	return lexeme, ok
+}
+
+// Keywords returns every reserved keyword lexeme in sorted order, for tools
+// such as syntax highlighters.
+func Keywords() []string {
+	lexemes := make([]string, 0, len(keywords))
+	for lexeme := range keywords {
+		lexemes = append(lexemes, lexeme)
+	}
+	sort.Strings(lexemes)
+	return lexemes
+}
+
+// IsIdentifier reports whether name scans as a single identifier rather than
+// a keyword, so that it can be written back as a bare object key. The English
+// keywords count as keywords even though a Scanner only reads them on request.
+func IsIdentifier(name string) bool {
+	for index, r := range name {
+		if !isAlphaNumeric(r) || (index == 0 && !isAlpha(r)) {
+			return false
+		}
+	}
+	_, isKeyword := keywords[name]
+	_, isEnglishKeyword := englishKeywords[name]
+	return name != "" && !isKeyword && !isEnglishKeyword
+}
+
+// englishKeywords are the English spellings of the logical operators that a
+// Scanner accepts alongside the Bangla ones when EnglishKeywords is set.
+var englishKeywords = map[string]token.TokenType{
+	"and": token.LOGICAL_AND,
+	"or":  token.LOGICAL_OR,
+	"not": token.BANG,
+}
+
+// ScanError describes a lexical error and where in the source it occurred.
+type ScanError struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e ScanError) Error() string {
+	return fmt.Sprintf("[line %d, column %d] Error: %s", e.Line, e.Column, e.Message)
 }
 
 type Scanner struct {
-	source  []rune
-	tokens  []token.Token
-	start   int
-	current int
-	line    int
+	source    []rune
+	tokens    []token.Token
+	errors    []ScanError
+	start     int
+	current   int
+	line      int
+	lineStart int
+
+	// Synchronize makes the scanner skip to the next whitespace after an
+	// unexpected character, so one bad run of input reports a single error.
+	Synchronize bool
+
+	// PreserveComments attaches each comment to the next token as leading
+	// trivia instead of discarding it. The token stream itself is unchanged.
+	PreserveComments bool
+	comments         []token.Comment
+
+	// EnglishKeywords makes and, or and not scan as the logical operators
+	// instead of identifiers, for programs written with English operators.
+	EnglishKeywords bool
 }
 
 // NewScanner creates a new Scanner instance
@@ -47,16 +139,25 @@ func NewScanner(source []rune) *Scanner {
 	}
 }
 
-// ScanTokens scans the source and returns the list of tokens
-func (s *Scanner) ScanTokens() []token.Token {
+// ScanTokens scans the source and returns the list of tokens along with every
+// error encountered. Errors are also reported through utils.GlobalError.
+func (s *Scanner) ScanTokens() ([]token.Token, []ScanError) {
 	for !s.isAtEnd() {
 		// We are at the beginning of the next lexeme.
 		s.start = s.current
 		s.scanToken()
 	}
 
-	s.tokens = append(s.tokens, *token.NewToken(token.EOF, "", nil, s.line))
-	return s.tokens
+	s.start = s.current
+	s.addToken(token.EOF)
+	return s.tokens, s.errors
+}
+
+// Tokens scans the source and returns only the tokens, for callers that rely
+// on utils.HadError to detect lexical errors.
+func (s *Scanner) Tokens() []token.Token {
+	tokens, _ := s.ScanTokens()
+	return tokens
 }
 
 // scanToken scans a single token
@@ -79,7 +180,24 @@ func (s *Scanner) scanToken() {
 	case ',':
 		s.addToken(token.COMMA)
 	case '.':
-		s.addToken(token.DOT)
+		if s.peek() == '.' && s.peekNext() == '.' {
+			s.advance()
+			s.advance()
+			s.addToken(token.ELLIPSIS)
+		} else if s.match('.') {
+			s.addToken(token.DOT_DOT)
+		} else {
+			s.addToken(token.DOT)
+		}
+	case '?':
+		if s.match('.') {
+			s.addToken(token.QUESTION_DOT)
+		} else {
+			s.error("Unexpected character.")
+			if s.Synchronize {
+				s.synchronize()
+			}
+		}
 	case '-':
 		s.addToken(token.MINUS)
 	case ':':
@@ -119,6 +237,8 @@ func (s *Scanner) scanToken() {
 	case '=':
 		if s.match('=') {
 			s.addToken(token.EQUAL_EQUAL)
+		} else if s.match('>') {
+			s.addToken(token.ARROW)
 		} else {
 			s.addToken(token.EQUAL)
 		}
@@ -145,15 +265,21 @@ func (s *Scanner) scanToken() {
 			for s.peek() != '\n' && !s.isAtEnd() {
 				s.advance()
 			}
+			s.comment(false, s.line, s.start-s.lineStart+1)
 		} else if s.match('*') {
-			s.multilineComment()
+			line, column := s.line, s.start-s.lineStart+1
+			if s.multilineComment() {
+				s.comment(true, line, column)
+			} else {
+				s.errorAt(line, column, "Unterminated multiline comment")
+			}
 		} else {
 			s.addToken(token.SLASH)
 		}
 	case ' ', '\r', '\t':
 		// Ignore whitespace
 	case '\n':
-		s.line++
+		s.newline()
 	case '"':
 		s.stringLiteral()
 	default:
@@ -162,21 +288,55 @@ func (s *Scanner) scanToken() {
 		} else if isAlpha(c) {
 			s.identifier()
 		} else {
-			utils.GlobalError(s.line, "Unexpected character.")
+			s.error("Unexpected character.")
+			if s.Synchronize {
+				s.synchronize()
+			}
 		}
 	}
 }
 
+// synchronize skips input up to the next whitespace after an error.
+func (s *Scanner) synchronize() {
+	for !s.isAtEnd() && !unicode.IsSpace(s.peek()) {
+		s.advance()
+	}
+}
+
+// error records a lexical error at the start of the current lexeme.
+func (s *Scanner) error(message string) {
+	s.errorAt(s.line, s.start-s.lineStart+1, message)
+}
+
+// errorAt reports an error at an earlier position, for a token that has
+// since run onto later lines.
+func (s *Scanner) errorAt(line, column int, message string) {
+	s.errors = append(s.errors, ScanError{
+		Line:    line,
+		Column:  column,
+		Message: message,
+	})
+	utils.GlobalError(line, message)
+}
+
+func (s *Scanner) newline() {
+	s.line++
+	s.lineStart = s.current
+}
+
 func (s *Scanner) identifier() {
 	for isAlphaNumeric(s.peek()) {
 		s.advance()
 	}
 
-	text := string(s.source[s.start:s.current])
+	// Names are normalized so either spelling of a letter such as য় works
+	text := utils.NormalizeName(string(s.source[s.start:s.current]))
 	if keyword, ok := keywords[text]; ok {
-		s.addToken(keyword)
+		s.addLexeme(keyword, text)
+	} else if keyword, ok := englishKeywords[text]; ok && s.EnglishKeywords {
+		s.addLexeme(keyword, text)
 	} else {
-		s.addToken(token.IDENTIFIER)
+		s.addLexeme(token.IDENTIFIER, text)
 	}
 }
 
@@ -186,7 +346,9 @@ func (s *Scanner) number() {
 	}
 
 	// Look for a fractional part.
+	integral := true
 	if s.peek() == '.' && isDigit(s.peekNext()) {
+		integral = false
 		// Consume the "."
 		s.advance()
 
@@ -198,45 +360,82 @@ func (s *Scanner) number() {
 	number_lexeme := utils.ConvertBanglaDigitsToASCII(string(s.source[s.start:s.current]))
 	value, err := strconv.ParseFloat(number_lexeme, 64)
 	if err != nil {
-		utils.GlobalError(s.line, "Invalid number format")
+		s.error("Invalid number format")
 		return
 	}
 
+	// Integers too large for a float64 to hold exactly keep every digit
+	if integral && (value >= utils.MaxSafeInteger || value <= -utils.MaxSafeInteger) {
+		exact, ok := new(big.Int).SetString(number_lexeme, 10)
+		if ok {
+			s.AddToken(token.NUMBER, exact)
+			return
+		}
+	}
+
 	s.AddToken(token.NUMBER, value)
 }
 
 func (s *Scanner) stringLiteral() {
 	for s.peek() != '"' && !s.isAtEnd() {
-		if s.peek() == '\n' {
-			s.line++
-		}
 		s.advance()
+		if s.previous() == '\n' {
+			s.newline()
+		}
 	}
 
 	if s.isAtEnd() {
-		utils.GlobalError(s.line, "Unterminated string.")
+		s.error("Unterminated string.")
 		return
 	}
 
 	s.advance()
 
-	value := s.source[s.start+1 : s.current-1]
+	// Strings are Go strings at runtime, however they were produced
+	value := string(s.source[s.start+1 : s.current-1])
 	s.AddToken(token.STRING, value)
 }
 
-func (s *Scanner) multilineComment() {
+// multilineComment skips a /* */ comment, along with any comments nested in
+// it, and reports whether it was closed before the end of the source.
+func (s *Scanner) multilineComment() bool {
+	// Comments nest, so each '/*' inside needs its own '*/'
+	depth := 1
 	for !s.isAtEnd() {
-		if s.peek() == '\n' {
-			s.line++
-		} else if s.peek() == '*' && s.peekNext() == '/' {
-			// Close the comment
+		if s.peek() == '/' && s.peekNext() == '*' {
+			s.advance() // consume /
 			s.advance() // consume *
-			s.advance() // consum /
-			return
+			depth++
+			continue
 		}
-		s.advance()
+		if s.peek() == '*' && s.peekNext() == '/' {
+			s.advance() // consume *
+			s.advance() // consume /
+			depth--
+			if depth == 0 {
+				return true
+			}
+			continue
+		}
+		if s.advance() == '\n' {
+			s.newline()
+		}
+	}
+	return false
+}
+
+// comment keeps the comment just scanned when PreserveComments is set, so it
+// can be attached to the next token.
+func (s *Scanner) comment(block bool, line, column int) {
+	if !s.PreserveComments {
+		return
 	}
-	utils.GlobalError(s.line, "Unterminated multiline comment")
+	s.comments = append(s.comments, token.Comment{
+		Text:   string(s.source[s.start:s.current]),
+		Block:  block,
+		Line:   line,
+		Column: column,
+	})
 }
 
 func (s *Scanner) match(expected rune) bool {
@@ -257,6 +456,10 @@ func (s *Scanner) peek() rune {
 	return s.source[s.current]
 }
 
+func (s *Scanner) previous() rune {
+	return s.source[s.current-1]
+}
+
 func (s *Scanner) peekNext() rune {
 	if s.current+1 >= len(s.source) {
 		return 0
@@ -294,6 +497,16 @@ func (s *Scanner) addToken(tokenType token.TokenType) {
 }
 
 func (s *Scanner) AddToken(tokenType token.TokenType, literal interface{}) {
-	text := string(s.source[s.start:s.current])
-	s.tokens = append(s.tokens, *token.NewToken(tokenType, text, literal, s.line))
+	s.addLiteral(tokenType, string(s.source[s.start:s.current]), literal)
+}
+
+// addLexeme adds a token whose lexeme differs from its source text.
+func (s *Scanner) addLexeme(tokenType token.TokenType, lexeme string) {
+	s.addLiteral(tokenType, lexeme, nil)
+}
+
+func (s *Scanner) addLiteral(tokenType token.TokenType, lexeme string, literal interface{}) {
+	t := token.NewToken(tokenType, lexeme, literal, s.line)
+	t.LeadingComments, s.comments = s.comments, nil
+	s.tokens = append(s.tokens, *t)
 }
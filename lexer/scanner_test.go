@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"io"
 	"os"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -51,6 +52,65 @@ func TestScanTokensBanglaKeywords(t *testing.T) {
 				token.EOF,
 			},
 		},
+		{
+			name:  "Spread and property access",
+			input: `f(...a, b.c)`,
+			expected: []token.TokenType{
+				token.IDENTIFIER,  // "f"
+				token.LEFT_PAREN,  // '('
+				token.ELLIPSIS,    // "..."
+				token.IDENTIFIER,  // "a"
+				token.COMMA,       // ','
+				token.IDENTIFIER,  // "b"
+				token.DOT,         // '.'
+				token.IDENTIFIER,  // "c"
+				token.RIGHT_PAREN, // ')'
+				token.EOF,
+			},
+		},
+		{
+			name:  "Optional chaining",
+			input: `a?.b?.[0]`,
+			expected: []token.TokenType{
+				token.IDENTIFIER,    // "a"
+				token.QUESTION_DOT,  // "?."
+				token.IDENTIFIER,    // "b"
+				token.QUESTION_DOT,  // "?."
+				token.LEFT_BRACKET,  // '['
+				token.NUMBER,        // "0"
+				token.RIGHT_BRACKET, // ']'
+				token.EOF,
+			},
+		},
+		{
+			name:  "Arrow",
+			input: `ফাংশন(x) => x >= 1 == y`,
+			expected: []token.TokenType{
+				token.FUN,           // "ফাংশন"
+				token.LEFT_PAREN,    // '('
+				token.IDENTIFIER,    // "x"
+				token.RIGHT_PAREN,   // ')'
+				token.ARROW,         // "=>"
+				token.IDENTIFIER,    // "x"
+				token.GREATER_EQUAL, // ">="
+				token.NUMBER,        // "1"
+				token.EQUAL_EQUAL,   // "=="
+				token.IDENTIFIER,    // "y"
+				token.EOF,
+			},
+		},
+		{
+			name:  "Range",
+			input: `1..10 ...a`,
+			expected: []token.TokenType{
+				token.NUMBER,     // "1"
+				token.DOT_DOT,    // ".."
+				token.NUMBER,     // "10"
+				token.ELLIPSIS,   // "..."
+				token.IDENTIFIER, // "a"
+				token.EOF,
+			},
+		},
 		{
 			name:     "Number literal",
 			input:    "২৩ ২৩.৩২",
@@ -163,6 +223,63 @@ func TestScanTokensBanglaKeywords(t *testing.T) {
 				token.EOF,
 			},
 		},
+		{
+			name:  "Switch keywords in Bangla",
+			input: `সুইচ (x) { ক্ষেত্রে 1: থামো; নইলে: }`,
+			expected: []token.TokenType{
+				token.SWITCH,      // "সুইচ"
+				token.LEFT_PAREN,  // '('
+				token.IDENTIFIER,  // "x"
+				token.RIGHT_PAREN, // ')'
+				token.LEFT_BRACE,  // '{'
+				token.CASE,        // "ক্ষেত্রে"
+				token.NUMBER,      // "1"
+				token.COLON,       // ':'
+				token.BREAK,       // "থামো"
+				token.SEMICOLON,   // ';'
+				token.DEFAULT,     // "নইলে"
+				token.COLON,       // ':'
+				token.RIGHT_BRACE, // '}'
+				token.EOF,
+			},
+		},
+		{
+			name:  "Foreach keywords in Bangla",
+			input: `প্রত্যেক (x ইন xs) থামো;`,
+			expected: []token.TokenType{
+				token.FOREACH,     // "প্রত্যেক"
+				token.LEFT_PAREN,  // '('
+				token.IDENTIFIER,  // "x"
+				token.IN,          // "ইন"
+				token.IDENTIFIER,  // "xs"
+				token.RIGHT_PAREN, // ')'
+				token.BREAK,       // "থামো"
+				token.SEMICOLON,   // ';'
+				token.EOF,
+			},
+		},
+		{
+			name:  "Exception keywords in Bangla",
+			input: `চেষ্টা {} ধরো (e) {} অবশেষে {} নিক্ষেপ e;`,
+			expected: []token.TokenType{
+				token.TRY,         // "চেষ্টা"
+				token.LEFT_BRACE,  // '{'
+				token.RIGHT_BRACE, // '}'
+				token.CATCH,       // "ধরো"
+				token.LEFT_PAREN,  // '('
+				token.IDENTIFIER,  // "e"
+				token.RIGHT_PAREN, // ')'
+				token.LEFT_BRACE,  // '{'
+				token.RIGHT_BRACE, // '}'
+				token.FINALLY,     // "অবশেষে"
+				token.LEFT_BRACE,  // '{'
+				token.RIGHT_BRACE, // '}'
+				token.THROW,       // "নিক্ষেপ"
+				token.IDENTIFIER,  // "e"
+				token.SEMICOLON,   // ';'
+				token.EOF,
+			},
+		},
 		{
 			name: "Logical operators in Bangla",
 			// (সত্য এবং মিথ্যা) বা মিথ্যা
@@ -189,7 +306,7 @@ func TestScanTokensBanglaKeywords(t *testing.T) {
 			capturedErr := CaptureStderr(func() {
 				// Pass the Bangla input as runes to NewScanner
 				scanner := NewScanner([]rune(tt.input))
-				tokens := scanner.ScanTokens()
+				tokens, _ := scanner.ScanTokens()
 
 				if len(tokens) != len(tt.expected) {
 					t.Errorf("Expected %d tokens, but got %d", len(tt.expected), len(tokens))
@@ -222,3 +339,233 @@ func TestScanTokensBanglaKeywords(t *testing.T) {
 		})
 	}
 }
+
+func TestScanErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		synchronize bool
+		expected    []ScanError
+	}{
+		{
+			name:     "No errors",
+			input:    `ধরি x = 1;`,
+			expected: nil,
+		},
+		{
+			name:  "Multiple unexpected characters",
+			input: "ধরি x = 1 @ 2;\nদেখাও $ x;",
+			expected: []ScanError{
+				{Line: 1, Column: 11, Message: "Unexpected character."},
+				{Line: 2, Column: 7, Message: "Unexpected character."},
+			},
+		},
+		{
+			name:  "Run of bad characters without synchronization",
+			input: "ab@@@ cd",
+			expected: []ScanError{
+				{Line: 1, Column: 3, Message: "Unexpected character."},
+				{Line: 1, Column: 4, Message: "Unexpected character."},
+				{Line: 1, Column: 5, Message: "Unexpected character."},
+			},
+		},
+		{
+			name:        "Run of bad characters with synchronization",
+			input:       "ab@@@x cd $$\n#",
+			synchronize: true,
+			expected: []ScanError{
+				{Line: 1, Column: 3, Message: "Unexpected character."},
+				{Line: 1, Column: 11, Message: "Unexpected character."},
+				{Line: 2, Column: 1, Message: "Unexpected character."},
+			},
+		},
+		{
+			name:     "Unterminated nested comment",
+			input:    "/* outer /* inner */ still open\nx",
+			expected: []ScanError{{Line: 1, Column: 1, Message: "Unterminated multiline comment"}},
+		},
+		{
+			name:  "Unterminated string after another error",
+			input: "@\n\"abc",
+			expected: []ScanError{
+				{Line: 1, Column: 1, Message: "Unexpected character."},
+				{Line: 2, Column: 1, Message: "Unterminated string."},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			utils.HadError = false
+
+			var errors []ScanError
+			CaptureStderr(func() {
+				scanner := NewScanner([]rune(tt.input))
+				scanner.Synchronize = tt.synchronize
+				_, errors = scanner.ScanTokens()
+			})
+
+			if len(errors) != len(tt.expected) {
+				t.Fatalf("Expected %d errors, but got %d: %v", len(tt.expected), len(errors), errors)
+			}
+			for i, expected := range tt.expected {
+				if errors[i] != expected {
+					t.Errorf("Error %d: expected %v, but got %v", i, expected, errors[i])
+				}
+			}
+			if len(tt.expected) > 0 && !utils.HadError {
+				t.Errorf("Expected errors to be reported through utils.HadError")
+			}
+		})
+	}
+}
+
+func TestPreserveComments(t *testing.T) {
+	input := "// note\nধরি x = 1; /* block\ncomment */ দেখাও x; // trailing"
+
+	scanner := NewScanner([]rune(input))
+	scanner.PreserveComments = true
+	tokens, errors := scanner.ScanTokens()
+	if len(errors) != 0 {
+		t.Fatalf("Unexpected errors: %v", errors)
+	}
+
+	expected := map[int][]token.Comment{
+		0: {{Text: "// note", Block: false, Line: 1, Column: 1}},
+		5: {{Text: "/* block\ncomment */", Block: true, Line: 2, Column: 12}},
+		8: {{Text: "// trailing", Block: false, Line: 3, Column: 21}},
+	}
+	for index, tok := range tokens {
+		if !reflect.DeepEqual(tok.LeadingComments, expected[index]) {
+			t.Errorf("Token %d (%q): expected comments %v, got %v", index, tok.Lexeme, expected[index], tok.LeadingComments)
+		}
+	}
+	if tokens[0].Type != token.VAR || tokens[5].Type != token.PRINT || tokens[8].Type != token.EOF {
+		t.Fatalf("Comments were attached to the wrong tokens: %v", tokens)
+	}
+
+	// The token stream is the same with and without the flag
+	plain, _ := NewScanner([]rune(input)).ScanTokens()
+	if len(plain) != len(tokens) {
+		t.Fatalf("Expected %d tokens without PreserveComments, got %d", len(tokens), len(plain))
+	}
+	for index := range plain {
+		if plain[index].Type != tokens[index].Type || plain[index].Lexeme != tokens[index].Lexeme {
+			t.Fatalf("Token %d differs: %v vs %v", index, plain[index], tokens[index])
+		}
+		if plain[index].LeadingComments != nil {
+			t.Fatalf("Token %d has comments without PreserveComments", index)
+		}
+	}
+}
+
+func TestEnglishKeywords(t *testing.T) {
+	input := "a and b or not c"
+
+	scanner := NewScanner([]rune(input))
+	scanner.EnglishKeywords = true
+	tokens, errors := scanner.ScanTokens()
+	if len(errors) != 0 {
+		t.Fatalf("Unexpected errors: %v", errors)
+	}
+	expected := []token.TokenType{
+		token.IDENTIFIER, token.LOGICAL_AND, token.IDENTIFIER, token.LOGICAL_OR,
+		token.BANG, token.IDENTIFIER, token.EOF,
+	}
+	if len(tokens) != len(expected) {
+		t.Fatalf("Expected %d tokens, got %d: %v", len(expected), len(tokens), tokens)
+	}
+	for index, tok := range tokens {
+		if tok.Type != expected[index] {
+			t.Errorf("Token %d (%q): expected %v, got %v", index, tok.Lexeme, expected[index], tok.Type)
+		}
+	}
+
+	// Without the flag the English words are ordinary identifiers
+	plain, _ := NewScanner([]rune(input)).ScanTokens()
+	for index, tok := range plain[:len(plain)-1] {
+		if tok.Type != token.IDENTIFIER {
+			t.Errorf("Token %d (%q): expected IDENTIFIER, got %v", index, tok.Lexeme, tok.Type)
+		}
+	}
+}
+
+func TestNestedComments(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []token.TokenType
+	}{
+		{"One level", "ধরি /* outer /* inner */ still comment */ x;", []token.TokenType{token.VAR, token.IDENTIFIER, token.SEMICOLON, token.EOF}},
+		{"Two levels", "/* a /* b /* c */ b */ a */ দেখাও 1;", []token.TokenType{token.PRINT, token.NUMBER, token.SEMICOLON, token.EOF}},
+		{"Across lines", "/* a\n/* b\n*/\n*/ x", []token.TokenType{token.IDENTIFIER, token.EOF}},
+		{"Star and slash apart", "/* a * / b */ x", []token.TokenType{token.IDENTIFIER, token.EOF}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			utils.HadError = false
+			tokens, errs := NewScanner([]rune(tt.input)).ScanTokens()
+			if len(errs) > 0 {
+				t.Fatalf("Unexpected errors: %v", errs)
+			}
+			if len(tokens) != len(tt.expected) {
+				t.Fatalf("Expected %d tokens, got %d: %v", len(tt.expected), len(tokens), tokens)
+			}
+			for index, expected := range tt.expected {
+				if tokens[index].Type != expected {
+					t.Errorf("Token %d: expected %v, got %v", index, expected, tokens[index].Type)
+				}
+			}
+		})
+	}
+
+	tokens, _ := NewScanner([]rune("/* a\n/* b\n*/\n*/ x")).ScanTokens()
+	if tokens[0].Line != 4 {
+		t.Fatalf("Expected the token after the comment on line 4, got line %d", tokens[0].Line)
+	}
+}
+
+func TestNamesAreNormalized(t *testing.T) {
+	// য় is one code point in the first spelling and য plus a nukta in the second
+	for _, pair := range [][2]string{
+		{"সম\u09DF", "সম\u09AF\u09BC"},
+		{"নাহ\u09DF", "নাহ\u09AF\u09BC"},
+	} {
+		first, _ := NewScanner([]rune(pair[0])).ScanTokens()
+		second, _ := NewScanner([]rune(pair[1])).ScanTokens()
+		if first[0].Type != second[0].Type || first[0].Lexeme != second[0].Lexeme {
+			t.Errorf("Expected %q and %q to scan alike, got %v %q and %v %q", pair[0], pair[1], first[0].Type, first[0].Lexeme, second[0].Type, second[0].Lexeme)
+		}
+	}
+}
+
+func TestKeywordLexeme(t *testing.T) {
+	if lexeme, ok := KeywordLexeme(token.FUN); !ok || lexeme != "ফাংশন" {
+		t.Fatalf("Expected FUN to map to ফাংশন, got %q (%v)", lexeme, ok)
+	}
+	if lexeme, ok := KeywordLexeme(token.LOGICAL_AND); !ok || lexeme != "এবং" {
+		t.Fatalf("Expected LOGICAL_AND to map to এবং, got %q (%v)", lexeme, ok)
+	}
+	if _, ok := KeywordLexeme(token.PLUS); ok {
+		t.Fatalf("Expected PLUS not to be a keyword")
+	}
+
+	// Every keyword scans back to the type it names
+	all := Keywords()
+	if len(all) != len(keywords) {
+		t.Fatalf("Expected %d keywords, got %d", len(keywords), len(all))
+	}
+	for index, lexeme := range all {
+		if index > 0 && all[index-1] >= lexeme {
+			t.Fatalf("Keywords are not sorted: %v", all)
+		}
+		tokens, errors := NewScanner([]rune(lexeme)).ScanTokens()
+		if len(errors) != 0 || tokens[0].Type != keywords[lexeme] {
+			t.Errorf("Keyword %q scanned as %v", lexeme, tokens[0].Type)
+		}
+		if canonical, ok := KeywordLexeme(keywords[lexeme]); !ok || canonical != lexeme {
+			t.Errorf("Expected %v to map back to %q, got %q", keywords[lexeme], lexeme, canonical)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/ah-naf/borno/borno"
 	"github.com/ah-naf/borno/interpreter"
 	"github.com/ah-naf/borno/lexer"
 	"github.com/ah-naf/borno/parser"
@@ -13,11 +14,19 @@ import (
 )
 
 func main() {
-	if len(os.Args) > 2 {
-		fmt.Println("Usage: borno [script]")
+	args := os.Args[1:]
+
+	// --test runs a script and reports how many assertions passed and failed
+	testMode := len(args) > 0 && args[0] == "--test"
+	if testMode {
+		args = args[1:]
+	}
+
+	if len(args) > 1 || (testMode && len(args) == 0) {
+		fmt.Println("Usage: borno [--test] [script]")
 		os.Exit(64)
-	} else if len(os.Args) == 2 {
-		scriptFile := os.Args[1]
+	} else if len(args) == 1 {
+		scriptFile := args[0]
 
 		// Extract the file extension.
 		ext := filepath.Ext(scriptFile) // e.g. ".bn" or ".borno"
@@ -27,7 +36,11 @@ func main() {
 			os.Exit(64)
 		}
 
-		runFile(scriptFile)
+		if testMode {
+			runTests(scriptFile)
+		} else {
+			runFile(scriptFile)
+		}
 	} else {
 		runPrompt()
 	}
@@ -40,7 +53,7 @@ func runFile(path string) {
 		fmt.Fprintf(os.Stderr, "Error: could not read file '%s': %v\n", path, err)
 		os.Exit(1)
 	}
-	run(string(rawContent), false)
+	run(interpreter.NewInterpreter(), string(rawContent), false)
 
 	if utils.HadError {
 		os.Exit(65)
@@ -50,7 +63,29 @@ func runFile(path string) {
 	}
 }
 
+func runTests(path string) {
+	report, err := borno.RunTests(path, os.Stdout)
+	if err != nil && !utils.HadError && !utils.HadRuntimeError {
+		fmt.Fprintf(os.Stderr, "Error: could not read file '%s': %v\n", path, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("%d passed, %d failed\n", report.Passed, report.Failed)
+
+	if utils.HadError {
+		os.Exit(65)
+	}
+	if utils.HadRuntimeError {
+		os.Exit(70)
+	}
+	if report.Failed > 0 {
+		os.Exit(1)
+	}
+}
+
 func runPrompt() {
+	// One interpreter is shared by every line so declarations persist
+	i := interpreter.NewInterpreter()
 	scanner := bufio.NewScanner(os.Stdin)
 	for {
 		fmt.Printf(">> ")
@@ -60,17 +95,17 @@ func runPrompt() {
 		}
 
 		line := scanner.Text()
-		run(line, true)
+		run(i, line, true)
 
 		utils.HadError = false
 		utils.HadRuntimeError = false
 	}
 }
 
-func run(source string, isRepl bool) {
+func run(i *interpreter.Interpreter, source string, isRepl bool) {
 	runeSource := []rune(source)
 	scanner := lexer.NewScanner(runeSource)
-	tokens := scanner.ScanTokens()
+	tokens, _ := scanner.ScanTokens()
 	// fmt.Printf("%#v\n", tokens)
 
 	Parser := parser.NewParser(tokens)
@@ -80,8 +115,7 @@ func run(source string, isRepl bool) {
 		return
 	}
 
-	interpreter := interpreter.NewInterpreter()
-	interpreter.Interpret(expr, isRepl)
+	i.Interpret(expr, isRepl)
 	if utils.HadRuntimeError {
 		return
 	}
@@ -8,26 +8,93 @@ import (
 	"github.com/ah-naf/borno/utils"
 )
 
-var reservedIdentifiers = map[string]bool{
-	"ক্লক":         true,
-	"লেন":          true,
-	"এড":           true,
-	"রিমুভ":        true,
-	"কি_রিমুভ":     true,
-	"অব্জেক্ট_কি":  true,
-	"অব্জেক্ট_মান": true,
-	"পরমমান":       true,
-	"বর্গমূল":      true,
-	"ঘাত":          true,
-	"সাইন":         true,
-	"কসাইন":        true,
-	"ট্যান":        true,
-	"সর্বনিম্ন":    true,
-	"সর্বোচ্চ":     true,
-	"রাউন্ড":       true,
-	"input":        true,
-	"ইনপুট":        true,
-}
+var reservedIdentifiers = utils.NormalizeNames(map[string]bool{
+	"ক্লক":     true,
+	"মনোটনিক":  true,
+	"সময়_মাপো": true,
+	"লেন":      true,
+	"খালি":     true,
+	"অ_খালি":   true,
+	"এড":       true,
+	"রিমুভ":    true,
+	"ঢুকাও":    true,
+	"স্লাইস":   true,
+	"সংযুক্ত":  true,
+	"পরিষ্কার": true,
+	"সমতল":     true,
+	"জিপ":      true,
+	"ম্যাপ":    true,
+	"ফিল্টার":  true,
+	"প্রত্যেক_উপাদান":   true,
+	"খুঁজে_পাও":         true,
+	"প্রতিটি":           true,
+	"কিছু":              true,
+	"কি_রিমুভ":          true,
+	"অব্জেক্ট_কি":       true,
+	"অব্জেক্ট_মান":      true,
+	"এন্ট্রি":           true,
+	"এন্ট্রি_থেকে":      true,
+	"কপি":               true,
+	"নেস্টেড_পাও":       true,
+	"নেস্টেড_সেট":       true,
+	"মান_ম্যাপ":         true,
+	"কি_ম্যাপ":          true,
+	"নিশ্চিত_সমান":      true,
+	"এরর":               true,
+	"আংশিক":             true,
+	"পরমমান":            true,
+	"বর্গমূল":           true,
+	"ঘাত":               true,
+	"সাইন":              true,
+	"কসাইন":             true,
+	"ট্যান":             true,
+	"সর্বনিম্ন":         true,
+	"সর্বোচ্চ":          true,
+	"রাউন্ড":            true,
+	"গোল_করে":           true,
+	"সীমাবদ্ধ":          true,
+	"চিহ্ন":             true,
+	"হাইপোট":            true,
+	"ভাগশেষ":            true,
+	"পরিসর":             true,
+	"টেমপ্লেট":          true,
+	"সাজাও_দ্বারা":      true,
+	"বিপরীত_সাজাও":      true,
+	"একত্র":             true,
+	"নির্বাচন":          true,
+	"বাদ":               true,
+	"গুণফল":             true,
+	"মধ্যমা":            true,
+	"মোড":               true,
+	"হেক্স":             true,
+	"বাইনারি":           true,
+	"পার্স_বেস":         true,
+	"প্রতিস্থাপন":       true,
+	"প্রতিস্থাপন_প্রথম": true,
+	"দিয়ে_শুরু":         true,
+	"দিয়ে_শেষ":          true,
+	"শুরু_ছাঁটো":        true,
+	"শেষ_ছাঁটো":         true,
+	"বড়হাতে":           true,
+	"ছোটহাতে":           true,
+	"ভাঙো":              true,
+	"রেজেক্স_ভাঙো":      true,
+	"মেলে":              true,
+	"খুঁজো":             true,
+	"সব_খুঁজো":          true,
+	"input":             true,
+	"ইনপুট":             true,
+	"সংখ্যা_ইনপুট":      true,
+	"সব_ইনপুট":          true,
+	"সতর্ক":             true,
+	"দেখাও_সহ":          true,
+	"গ্লোবাল_পাও":       true,
+	"গ্লোবাল_সেট":       true,
+	"সংজ্ঞায়িত":         true,
+	"সংখ্যায়":           true,
+	"ধরন":               true,
+	"আছে_কি":            true,
+})
 
 type ParseError struct {
 	message string
@@ -40,6 +107,13 @@ func (e ParseError) Error() string {
 type Parser struct {
 	tokens  []token.Token
 	current int
+	errors  []error
+
+	// Enclosing loops, switches and functions of the statement being parsed,
+	// used to reject a misplaced break, continue or return before execution.
+	loopDepth     int
+	switchDepth   int
+	functionDepth int
 }
 
 func NewParser(tokens []token.Token) *Parser {
@@ -48,22 +122,37 @@ func NewParser(tokens []token.Token) *Parser {
 	}
 }
 
-func (p *Parser) Parse() ([]ast.Stmt, error) {
+// Parse parses every declaration in the token stream. After a syntax error it
+// skips to the next statement boundary and keeps going, so it returns all the
+// statements that parsed successfully along with every error encountered.
+func (p *Parser) Parse() ([]ast.Stmt, []error) {
 	statments := []ast.Stmt{}
 
 	for !p.isAtEnd() {
-		stmt, err := p.declaration()
-		if err != nil {
-			return nil, err
+		if stmt := p.declaration(); stmt != nil {
+			statments = append(statments, stmt)
 		}
-		statments = append(statments, stmt)
 	}
 
-	return statments, nil
+	return statments, p.errors
 }
 
-func (p *Parser) declaration() (ast.Stmt, error) {
-	if p.match(token.FUN) {
+// declaration parses a single declaration. On a syntax error it records the
+// error, synchronizes and returns a nil statement.
+func (p *Parser) declaration() ast.Stmt {
+	stmt, err := p.declarationOrError()
+	if err != nil {
+		p.errors = append(p.errors, err)
+		p.synchronize()
+		return nil
+	}
+	return stmt
+}
+
+func (p *Parser) declarationOrError() (ast.Stmt, error) {
+	// ফাংশন followed by '(' starts an anonymous function used as a value
+	if p.check(token.FUN) && !p.checkNext(token.LEFT_PAREN) {
+		p.advance()
 		return p.function("function")
 	}
 	if p.match(token.VAR) {
@@ -73,10 +162,37 @@ func (p *Parser) declaration() (ast.Stmt, error) {
 }
 
 func (p *Parser) varDeclaration() (ast.Stmt, error) {
+	declaration, err := p.varDeclarationList()
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure semicolon at the end of the declaration
+	_, err = p.consume(token.SEMICOLON, "Expect ';' after variable declaration.")
+	if err != nil {
+		return nil, err
+	}
+	return declaration, nil
+}
+
+// varDeclarationList parses the comma-separated variables after ধরি, up to
+// but not including the terminating ';'.
+func (p *Parser) varDeclarationList() (ast.Stmt, error) {
 	var declarations []ast.VarStmt
-	initialLine := p.peek().Line // Track the line number at the start of the declaration
 
 	for {
+		if p.match(token.LEFT_BRACKET) {
+			declaration, err := p.arrayPattern()
+			if err != nil {
+				return nil, err
+			}
+			declarations = append(declarations, *declaration)
+			if !p.match(token.COMMA) {
+				break
+			}
+			continue
+		}
+
 		// Parse the variable name
 		name, err := p.consume(token.IDENTIFIER, "Expect variable name.")
 		if err != nil {
@@ -102,36 +218,55 @@ func (p *Parser) varDeclaration() (ast.Stmt, error) {
 		declaration := &ast.VarStmt{Name: name, Initializer: initializer, Line: name.Line}
 		declarations = append(declarations, *declaration)
 
-		// Check for newline and semicolon before proceeding to the next variable,
-		// but skip this check if the initializer is an object or array literal.
-		switch initializer.(type) {
-		case *ast.ObjectLiteral, *ast.ArrayLiteral:
-			// Skip newline check for ObjectLiteral and ArrayLiteral
-		default:
-			if p.peek().Line != initialLine {
-				return nil, p.error(p.peek(), "Expect ';' before newline.")
-			}
-		}
-
 		// If no more commas, break out of the loop
 		if !p.match(token.COMMA) {
 			break
 		}
 	}
 
-	// Ensure semicolon at the end of the declaration
-	_, err := p.consume(token.SEMICOLON, "Expect ';' after variable declaration.")
-	if err != nil {
-		return nil, err
-	}
-
 	// If there's only one variable, return it directly
 	if len(declarations) == 1 {
 		return &declarations[0], nil
 	}
 
 	// If there are multiple variables, return a VarListStmt
-	return &ast.VarListStmt{Declarations: declarations}, nil
+	return &ast.VarListStmt{Declarations: declarations, Line: declarations[0].Line}, nil
+}
+
+// arrayPattern parses the rest of a declaration like ধরি [a, _, b] = list
+// after its '['. The pattern always needs an initializer to take apart.
+func (p *Parser) arrayPattern() (*ast.VarStmt, error) {
+	line := p.previous().Line
+	var names []token.Token
+	if !p.check(token.RIGHT_BRACKET) {
+		for {
+			name, err := p.consume(token.IDENTIFIER, "Expect variable name in array pattern.")
+			if err != nil {
+				return nil, err
+			}
+			if _, isReserved := reservedIdentifiers[name.Lexeme]; isReserved {
+				return nil, p.error(name, fmt.Sprintf("'%s' is a reserved identifier and cannot be used as a variable name.", name.Lexeme))
+			}
+			names = append(names, name)
+			if !p.match(token.COMMA) {
+				break
+			}
+		}
+	}
+	if _, err := p.consume(token.RIGHT_BRACKET, "Expect ']' after array pattern."); err != nil {
+		return nil, err
+	}
+	if _, err := p.consume(token.EQUAL, "Expect '=' after array pattern."); err != nil {
+		return nil, err
+	}
+	initializer, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+	if names == nil {
+		names = []token.Token{}
+	}
+	return &ast.VarStmt{Pattern: names, Initializer: initializer, Line: line}, nil
 }
 
 func (p *Parser) statement() (ast.Stmt, error) {
@@ -144,6 +279,18 @@ func (p *Parser) statement() (ast.Stmt, error) {
 	if p.match(token.FOR) {
 		return p.forStatement()
 	}
+	if p.match(token.FOREACH) {
+		return p.forEachStatement()
+	}
+	if p.match(token.SWITCH) {
+		return p.switchStatement()
+	}
+	if p.match(token.TRY) {
+		return p.tryStatement()
+	}
+	if p.match(token.THROW) {
+		return p.throwStatement()
+	}
 	if p.match(token.PRINT) {
 		return p.printStatement()
 	}
@@ -151,88 +298,370 @@ func (p *Parser) statement() (ast.Stmt, error) {
 		return p.returnStatement()
 	}
 	if p.match(token.BREAK) {
+		keyword := p.previous()
 		_, err := p.consume(token.SEMICOLON, "Expected ; after break.")
 		if err != nil {
 			return nil, err
 		}
+		if p.loopDepth == 0 && p.switchDepth == 0 {
+			p.misplaced(keyword, "Can't use '"+keyword.Lexeme+"' outside of a loop or switch.")
+		}
 		return &ast.BreakStmt{Line: p.previous().Line}, nil
 	}
 	if p.match(token.CONTINUE) {
+		keyword := p.previous()
 		_, err := p.consume(token.SEMICOLON, "Expected ; after continue.")
 		if err != nil {
 			return nil, err
 		}
+		if p.loopDepth == 0 {
+			p.misplaced(keyword, "Can't use '"+keyword.Lexeme+"' outside of a loop.")
+		}
 		return &ast.ContinueStmt{Line: p.previous().Line}, nil
 	}
 
+	// A '{' starting a statement opens a block unless it is clearly an object
+	// literal used as an expression statement
+	if p.check(token.LEFT_BRACE) && p.startsObjectLiteral() {
+		return p.expressionStatement()
+	}
+
 	if p.match(token.LEFT_BRACE) {
+		line := p.previous().Line
 		blocks, err := p.block()
 		if err != nil {
 			return nil, err
 		}
-		return &ast.BlockStmt{Block: blocks}, nil
+		return &ast.BlockStmt{Block: blocks, Line: line}, nil
 	}
 
 	return p.expressionStatement()
 }
 
 func (p *Parser) forStatement() (ast.Stmt, error) {
+	line := p.previous().Line
 	_, err := p.consume(token.LEFT_PAREN, "Expect '(' after 'for'.")
 	if err != nil {
 		return nil, err
 	}
 
+	initializer, condition, increments, err := p.forClauses()
+	if err != nil {
+		p.skipForHeader()
+		return nil, err
+	}
+
+	body, err := p.loopBody()
+	if err != nil {
+		return nil, err
+	}
+
+	if condition == nil {
+		condition = &ast.Literal{Value: true, Line: line}
+	}
+
+	return &ast.ForStmt{Initializer: initializer, Condition: condition, Body: body, Increments: increments, Line: line}, nil
+}
+
+// forClauses parses the three clauses of a for header and its closing ')'.
+// Each missing ';' is reported at the clause it should have ended. The last
+// clause may hold several comma-separated increments.
+func (p *Parser) forClauses() (ast.Stmt, ast.Expr, []ast.Expr, error) {
 	var initializer ast.Stmt
-	if p.match(token.SEMICOLON) {
-		initializer = nil
-	} else if p.match(token.VAR) {
-		initializer, err = p.varDeclaration()
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		initializer, err = p.expressionStatement()
-		if err != nil {
-			return nil, err
-		}
+	var err error
+	if p.match(token.VAR) {
+		initializer, err = p.varDeclarationList()
+	} else if !p.check(token.SEMICOLON) {
+		line := p.peek().Line
+		var value ast.Expr
+		value, err = p.expression()
+		initializer = &ast.ExpressionStatement{Expression: value, Line: line}
 	}
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	_, err = p.consume(token.SEMICOLON, "Expect ';' after loop initializer.")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
 	var condition ast.Expr
 	if !p.check(token.SEMICOLON) {
 		condition, err = p.expression()
 		if err != nil {
-			return nil, err
+			return nil, nil, nil, err
 		}
 	}
 	_, err = p.consume(token.SEMICOLON, "Expect ';' after loop condition.")
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 
-	var increment ast.Expr
+	var increments []ast.Expr
 	if !p.check(token.RIGHT_PAREN) {
-		increment, err = p.expression()
+		for {
+			increment, err := p.expression()
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			increments = append(increments, increment)
+			if !p.match(token.COMMA) {
+				break
+			}
+		}
+	}
+	_, err = p.consume(token.RIGHT_PAREN, "Expect ')' after for clauses.")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return initializer, condition, increments, nil
+}
+
+// skipForHeader discards the rest of a malformed for header and the block
+// after it, so the loop body is not parsed as if it followed the loop and
+// reported again. It stops before the body's closing '}', which synchronize
+// then steps over.
+func (p *Parser) skipForHeader() {
+	depth := 0
+	for !p.isAtEnd() && !p.check(token.LEFT_BRACE) {
+		if p.check(token.LEFT_PAREN) {
+			depth++
+		} else if p.check(token.RIGHT_PAREN) {
+			if depth == 0 {
+				p.advance()
+				break
+			}
+			depth--
+		}
+		p.advance()
+	}
+
+	if !p.check(token.LEFT_BRACE) {
+		return
+	}
+	braces := 0
+	for !p.isAtEnd() {
+		if p.check(token.LEFT_BRACE) {
+			braces++
+		} else if p.check(token.RIGHT_BRACE) {
+			braces--
+			if braces == 0 {
+				return
+			}
+		}
+		p.advance()
+	}
+}
+
+func (p *Parser) forEachStatement() (ast.Stmt, error) {
+	line := p.previous().Line
+	_, err := p.consume(token.LEFT_PAREN, "Expect '(' after 'foreach'.")
+	if err != nil {
+		return nil, err
+	}
+
+	variable, err := p.consume(token.IDENTIFIER, "Expect loop variable name.")
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = p.consume(token.IN, "Expect 'in' after loop variable.")
+	if err != nil {
+		return nil, err
+	}
+
+	iterable, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = p.consume(token.RIGHT_PAREN, "Expect ')' after foreach clause.")
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := p.loopBody()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ast.ForEachStmt{Variable: variable, Iterable: iterable, Body: body, Line: line}, nil
+}
+
+func (p *Parser) switchStatement() (ast.Stmt, error) {
+	line := p.previous().Line
+	_, err := p.consume(token.LEFT_PAREN, "Expect '(' after 'switch'.")
+	if err != nil {
+		return nil, err
+	}
+
+	discriminant, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = p.consume(token.RIGHT_PAREN, "Expect ')' after switch value.")
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.consume(token.LEFT_BRACE, "Expect '{' before switch body.")
+	if err != nil {
+		return nil, err
+	}
+
+	stmt := &ast.SwitchStmt{Discriminant: discriminant, Line: line}
+	for !p.check(token.RIGHT_BRACE) && !p.isAtEnd() {
+		if p.match(token.CASE) {
+			caseLine := p.previous().Line
+			values, err := p.caseValues()
+			if err != nil {
+				return nil, err
+			}
+			_, err = p.consume(token.COLON, "Expect ':' after case value.")
+			if err != nil {
+				return nil, err
+			}
+			stmt.Cases = append(stmt.Cases, ast.SwitchCase{Values: values, Body: p.caseBody(), Line: caseLine})
+		} else if p.match(token.DEFAULT) {
+			if stmt.HasDefault {
+				return nil, p.error(p.previous(), "A switch can only have one default clause.")
+			}
+			_, err := p.consume(token.COLON, "Expect ':' after 'default'.")
+			if err != nil {
+				return nil, err
+			}
+			stmt.Default = p.caseBody()
+			stmt.HasDefault = true
+		} else {
+			return nil, p.error(p.peek(), "Expect 'case' or 'default' in switch body.")
+		}
+	}
+
+	_, err = p.consume(token.RIGHT_BRACE, "Expect '}' after switch body.")
+	if err != nil {
+		return nil, err
+	}
+	return stmt, nil
+}
+
+// caseValues parses the comma-separated values of a case clause. Each value
+// is an expression or a low..high range.
+func (p *Parser) caseValues() ([]ast.Expr, error) {
+	var values []ast.Expr
+	for {
+		value, err := p.expression()
 		if err != nil {
 			return nil, err
 		}
+		if p.match(token.DOT_DOT) {
+			line := p.previous().Line
+			high, err := p.expression()
+			if err != nil {
+				return nil, err
+			}
+			value = &ast.CaseRange{Low: value, High: high, Line: line}
+		}
+		values = append(values, value)
+
+		if !p.match(token.COMMA) {
+			return values, nil
+		}
 	}
-	_, err = p.consume(token.RIGHT_PAREN, "Expect ')' after for clauses.")
+}
+
+func (p *Parser) tryStatement() (ast.Stmt, error) {
+	line := p.previous().Line
+	brace, err := p.consume(token.LEFT_BRACE, "Expect '{' after 'try'.")
+	if err != nil {
+		return nil, err
+	}
+	body, err := p.block()
 	if err != nil {
 		return nil, err
 	}
 
-	body, err := p.statement()
+	stmt := &ast.TryStmt{Body: &ast.BlockStmt{Block: body, Line: brace.Line}, Line: line}
+	if p.match(token.CATCH) {
+		_, err := p.consume(token.LEFT_PAREN, "Expect '(' after 'catch'.")
+		if err != nil {
+			return nil, err
+		}
+		name, err := p.consume(token.IDENTIFIER, "Expect catch variable name.")
+		if err != nil {
+			return nil, err
+		}
+		_, err = p.consume(token.RIGHT_PAREN, "Expect ')' after catch variable.")
+		if err != nil {
+			return nil, err
+		}
+		brace, err := p.consume(token.LEFT_BRACE, "Expect '{' before catch body.")
+		if err != nil {
+			return nil, err
+		}
+		catch, err := p.block()
+		if err != nil {
+			return nil, err
+		}
+		stmt.CatchName = name
+		stmt.Catch = &ast.BlockStmt{Block: catch, Line: brace.Line}
+		stmt.HasCatch = true
+	}
+	if p.match(token.FINALLY) {
+		brace, err := p.consume(token.LEFT_BRACE, "Expect '{' after 'finally'.")
+		if err != nil {
+			return nil, err
+		}
+		finally, err := p.block()
+		if err != nil {
+			return nil, err
+		}
+		stmt.Finally = &ast.BlockStmt{Block: finally, Line: brace.Line}
+		stmt.HasFinally = true
+	}
+
+	if !stmt.HasCatch && !stmt.HasFinally {
+		return nil, p.error(p.peek(), "Expect 'catch' or 'finally' after try block.")
+	}
+	return stmt, nil
+}
+
+func (p *Parser) throwStatement() (ast.Stmt, error) {
+	keyword := p.previous()
+	value, err := p.expression()
 	if err != nil {
 		return nil, err
 	}
+	_, err = p.consume(token.SEMICOLON, "Expect ';' after thrown value.")
+	if err != nil {
+		return nil, err
+	}
+	return &ast.ThrowStmt{Keyword: keyword, Value: value, Line: keyword.Line}, nil
+}
 
-	if condition == nil {
-		condition = &ast.Literal{Value: true}
+// caseBody parses the statements of a case clause, up to the next clause or
+// the end of the switch.
+func (p *Parser) caseBody() []ast.Stmt {
+	p.switchDepth++
+	defer func() { p.switchDepth-- }()
+
+	statements := []ast.Stmt{}
+	for !p.check(token.CASE) && !p.check(token.DEFAULT) && !p.check(token.RIGHT_BRACE) && !p.isAtEnd() {
+		if decl := p.declaration(); decl != nil {
+			statements = append(statements, decl)
+		}
 	}
+	return statements
+}
 
-	return &ast.ForStmt{Initializer: initializer, Condition: condition, Body: body, Increment: increment}, nil
+// loopBody parses the body of a loop, where break and continue are allowed.
+func (p *Parser) loopBody() (ast.Stmt, error) {
+	p.loopDepth++
+	defer func() { p.loopDepth-- }()
+	return p.statement()
 }
 
 func (p *Parser) while() (ast.Stmt, error) {
+	line := p.previous().Line
 	_, err := p.consume(token.LEFT_PAREN, "Expect '(' after 'while'.")
 	if err != nil {
 		return nil, err
@@ -248,15 +677,16 @@ func (p *Parser) while() (ast.Stmt, error) {
 		return nil, err
 	}
 
-	body, err := p.statement()
+	body, err := p.loopBody()
 	if err != nil {
 		return nil, err
 	}
 
-	return &ast.While{Condition: condition, Body: body}, nil
+	return &ast.While{Condition: condition, Body: body, Line: line}, nil
 }
 
 func (p *Parser) IfStatement() (ast.Stmt, error) {
+	line := p.previous().Line
 	_, err := p.consume(token.LEFT_PAREN, "Expect '(' after 'if'.")
 	if err != nil {
 		return nil, err
@@ -282,16 +712,28 @@ func (p *Parser) IfStatement() (ast.Stmt, error) {
 		}
 		elseBranch = v
 	}
-	return &ast.IfStmt{Condition: condition, ThenBranch: thenBranch, ElseBranch: elseBranch}, nil
+	return &ast.IfStmt{Condition: condition, ThenBranch: thenBranch, ElseBranch: elseBranch, Line: line}, nil
 }
 
 func (p *Parser) printStatement() (ast.Stmt, error) {
-	value, err := p.expression()
+	line := p.previous().Line
+	values := []ast.Expr{}
+	for {
+		value, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+
+		if !p.match(token.COMMA) {
+			break
+		}
+	}
+	_, err := p.consume(token.SEMICOLON, "Expect ';' after value.")
 	if err != nil {
 		return nil, err
 	}
-	p.consume(token.SEMICOLON, "Expect ';' after value.")
-	return &ast.PrintStatement{Expression: value}, nil
+	return &ast.PrintStatement{Expressions: values, Line: line}, nil
 }
 
 func (p *Parser) returnStatement() (ast.Stmt, error) {
@@ -311,16 +753,26 @@ func (p *Parser) returnStatement() (ast.Stmt, error) {
 		return nil, err
 	}
 
-	return &ast.Return{Keyword: keyword, Value: value}, nil
+	if p.functionDepth == 0 {
+		p.misplaced(keyword, "Can't use '"+keyword.Lexeme+"' outside of a function.")
+	}
+
+	return &ast.Return{Keyword: keyword, Value: value, Line: keyword.Line}, nil
 }
 
+// Every statement must end with an explicit ';'. Newlines are not statement
+// terminators, so a missing semicolon is always a syntax error.
 func (p *Parser) expressionStatement() (ast.Stmt, error) {
+	line := p.peek().Line
 	value, err := p.expression()
 	if err != nil {
 		return nil, err
 	}
-	p.consume(token.SEMICOLON, "Expect ';' after value.")
-	return &ast.ExpressionStatement{Expression: value}, nil
+	_, err = p.consume(token.SEMICOLON, "Expect ';' after value.")
+	if err != nil {
+		return nil, err
+	}
+	return &ast.ExpressionStatement{Expression: value, Line: line}, nil
 }
 
 func (p *Parser) function(kind string) (ast.Stmt, error) {
@@ -337,7 +789,81 @@ func (p *Parser) function(kind string) (ast.Stmt, error) {
 	if err != nil {
 		return nil, err
 	}
+	parameters, err := p.parameters()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = p.consume(token.LEFT_BRACE, "Expect '{' before "+kind+" body.")
+	if err != nil {
+		return nil, err
+	}
+
+	// A function body starts outside of any loop or switch
+	enclosingLoops, enclosingSwitches := p.loopDepth, p.switchDepth
+	p.loopDepth, p.switchDepth = 0, 0
+	p.functionDepth++
+	body, err := p.block()
+	p.functionDepth--
+	p.loopDepth, p.switchDepth = enclosingLoops, enclosingSwitches
+	if err != nil {
+		return nil, err
+	}
 
+	return &ast.FunctionStmt{Name: name, Params: parameters, Body: body, Line: name.Line}, nil
+}
+
+// functionExpression parses an anonymous function after its ফাংশন keyword.
+// The body is either a block, ফাংশন(x) { ফেরত x * 2; }, or the arrow form
+// ফাংশন(x) => x * 2, whose expression is the body and the return value.
+func (p *Parser) functionExpression() (ast.Expr, error) {
+	keyword := p.previous()
+	_, err := p.consume(token.LEFT_PAREN, "Expect '(' after 'ফাংশন'.")
+	if err != nil {
+		return nil, err
+	}
+	parameters, err := p.parameters()
+	if err != nil {
+		return nil, err
+	}
+	isArrow := p.match(token.ARROW)
+	if !isArrow {
+		_, err = p.consume(token.LEFT_BRACE, "Expect '{' or '=>' after anonymous function parameters.")
+		if err != nil {
+			return nil, err
+		}
+	}
+	arrow := p.previous()
+
+	enclosingLoops, enclosingSwitches := p.loopDepth, p.switchDepth
+	p.loopDepth, p.switchDepth = 0, 0
+	p.functionDepth++
+	var body []ast.Stmt
+	if isArrow {
+		var value ast.Expr
+		value, err = p.expression()
+		body = []ast.Stmt{&ast.Return{Keyword: arrow, Value: value, Line: arrow.Line}}
+	} else {
+		body, err = p.block()
+	}
+	p.functionDepth--
+	p.loopDepth, p.switchDepth = enclosingLoops, enclosingSwitches
+	if err != nil {
+		return nil, err
+	}
+
+	declaration := &ast.FunctionStmt{
+		Name:   token.Token{Type: token.FUN, Line: keyword.Line},
+		Params: parameters,
+		Body:   body,
+		Line:   keyword.Line,
+	}
+	return &ast.FunctionExpr{Declaration: declaration, Arrow: isArrow, Line: keyword.Line}, nil
+}
+
+// parameters parses a parameter list after its '(' up to and including the
+// closing ')'.
+func (p *Parser) parameters() ([]token.Token, error) {
 	parameters := []token.Token{}
 	if !p.check(token.RIGHT_PAREN) {
 		for {
@@ -351,41 +877,32 @@ func (p *Parser) function(kind string) (ast.Stmt, error) {
 			}
 			parameters = append(parameters, pp)
 
-			if !p.match(token.COMMA) {
+			// A trailing comma may come before the closing parenthesis
+			if !p.match(token.COMMA) || p.check(token.RIGHT_PAREN) {
 				break
 			}
 		}
 	}
-	_, err = p.consume(token.RIGHT_PAREN, "Expect ')' after parameters.")
+	_, err := p.consume(token.RIGHT_PAREN, "Expect ')' after parameters.")
 	if err != nil {
 		return nil, err
 	}
-
-	_, err = p.consume(token.LEFT_BRACE, "Expect '{' before "+kind+" body.")
-	if err != nil {
-		return nil, err
-	}
-
-	body, err := p.block()
-	if err != nil {
-		return nil, err
-	}
-
-	return &ast.FunctionStmt{Name: name, Params: parameters, Body: body}, nil
+	return parameters, nil
 }
 
 func (p *Parser) block() ([]ast.Stmt, error) {
 	statments := []ast.Stmt{}
 
 	for !p.check(token.RIGHT_BRACE) && !p.isAtEnd() {
-		decl, err := p.declaration()
-		if err != nil {
-			return nil, err
+		if decl := p.declaration(); decl != nil {
+			statments = append(statments, decl)
 		}
-		statments = append(statments, decl)
 	}
 
-	p.consume(token.RIGHT_BRACE, "Expect '}' after block.")
+	_, err := p.consume(token.RIGHT_BRACE, "Expect '}' after block.")
+	if err != nil {
+		return nil, err
+	}
 	return statments, nil
 }
 
@@ -421,6 +938,9 @@ func (p *Parser) assignment() (ast.Expr, error) {
 				Line:  equalOperator.Line,
 			}, nil
 		case *ast.ArrayAccess:
+			if target.Optional {
+				return nil, p.error(equalOperator, "Invalid assignment target.")
+			}
 			// If the left-hand side is an array access, it's also a valid assignment target
 			return &ast.ArrayAssignment{
 				Array: target.Array,
@@ -429,6 +949,9 @@ func (p *Parser) assignment() (ast.Expr, error) {
 				Line:  equalOperator.Line,
 			}, nil
 		case *ast.PropertyAccess:
+			if target.Optional {
+				return nil, p.error(equalOperator, "Invalid assignment target.")
+			}
 			// Handle object property access assignment
 			return &ast.PropertyAssignment{
 				Object:   target.Object,
@@ -459,7 +982,7 @@ func (p *Parser) logicalOR() (ast.Expr, error) {
 			return nil, err
 		}
 
-		expr = &ast.Logical{Left: expr, Operator: operator, Right: right}
+		expr = &ast.Logical{Left: expr, Operator: operator, Right: right, Line: operator.Line}
 	}
 
 	return expr, nil
@@ -478,7 +1001,7 @@ func (p *Parser) logicalAnd() (ast.Expr, error) {
 			return nil, err
 		}
 
-		expr = &ast.Logical{Left: expr, Operator: operator, Right: right}
+		expr = &ast.Logical{Left: expr, Operator: operator, Right: right, Line: operator.Line}
 	}
 
 	return expr, nil
@@ -650,6 +1173,8 @@ func (p *Parser) factor() (ast.Expr, error) {
 	return expr, nil
 }
 
+// power parses '**', which is right-associative: 2 ** 3 ** 2 is 2 ** (3 ** 2).
+// Its operands are unary expressions, so -2 ** 2 is (-2) ** 2.
 func (p *Parser) power() (ast.Expr, error) {
 	expr, err := p.unary()
 
@@ -657,9 +1182,9 @@ func (p *Parser) power() (ast.Expr, error) {
 		return nil, err
 	}
 
-	for p.match(token.POWER) {
+	if p.match(token.POWER) {
 		operator := p.previous()
-		right, err := p.unary()
+		right, err := p.power()
 
 		if err != nil {
 			return nil, err
@@ -672,7 +1197,7 @@ func (p *Parser) power() (ast.Expr, error) {
 }
 
 func (p *Parser) unary() (ast.Expr, error) {
-	if p.match(token.BANG, token.MINUS, token.NOT) {
+	if p.match(token.BANG, token.MINUS, token.PLUS, token.NOT) {
 		operator := p.previous()
 		right, err := p.unary()
 
@@ -720,6 +1245,26 @@ func (p *Parser) call() (ast.Expr, error) {
 				return nil, err
 			}
 			expr = &ast.PropertyAccess{Object: expr, Property: propName, Line: p.previous().Line}
+		} else if p.match(token.QUESTION_DOT) {
+			// Optional access evaluates to nil instead of failing on a nil receiver
+			if p.match(token.LEFT_BRACKET) {
+				index, err := p.expression()
+				if err != nil {
+					return nil, err
+				}
+
+				_, err = p.consume(token.RIGHT_BRACKET, "Expect ']' after array index.")
+				if err != nil {
+					return nil, err
+				}
+				expr = &ast.ArrayAccess{Array: expr, Index: index, Optional: true, Line: p.previous().Line}
+			} else {
+				propName, err := p.consume(token.IDENTIFIER, "Expect property name or '[' after '?.'.")
+				if err != nil {
+					return nil, err
+				}
+				expr = &ast.PropertyAccess{Object: expr, Property: propName, Optional: true, Line: p.previous().Line}
+			}
 		} else {
 			break // No more call expressions to parse.
 		}
@@ -728,19 +1273,21 @@ func (p *Parser) call() (ast.Expr, error) {
 }
 
 func (p *Parser) finishCall(callee ast.Expr) (ast.Expr, error) {
+	line := p.previous().Line
 	// Parse the arguments inside the parentheses.
 	arguments := []ast.Expr{}
 
 	if !p.check(token.RIGHT_PAREN) { // If there are arguments to parse.
 		for {
-			arg, err := p.expression()
+			arg, err := p.spreadOrExpression()
 			if err != nil {
 				return nil, err
 			}
 			arguments = append(arguments, arg)
 
-			// Continue parsing arguments separated by commas.
-			if !p.match(token.COMMA) {
+			// Continue parsing arguments separated by commas, allowing a
+			// trailing comma before the closing parenthesis.
+			if !p.match(token.COMMA) || p.check(token.RIGHT_PAREN) {
 				break
 			}
 		}
@@ -757,6 +1304,7 @@ func (p *Parser) finishCall(callee ast.Expr) (ast.Expr, error) {
 		Callee:    callee,
 		Paren:     paren,     // This stores the right parenthesis token for error reporting.
 		Arguments: arguments, // The list of parsed arguments.
+		Line:      line,      // The line of the opening parenthesis.
 	}, nil
 }
 
@@ -779,6 +1327,10 @@ func (p *Parser) primary() (ast.Expr, error) {
 		return &ast.Identifier{Name: p.previous(), Line: p.previous().Line}, nil
 	}
 
+	if p.match(token.FUN) {
+		return p.functionExpression()
+	}
+
 	if p.match(token.LEFT_PAREN) {
 		expr, err := p.expression()
 
@@ -808,17 +1360,39 @@ func (p *Parser) primary() (ast.Expr, error) {
 	return nil, p.error(p.peek(), "Unexpected token. Expect expression.")
 }
 
+// startsObjectLiteral reports whether the '{' at the current token begins an
+// object literal: either "{ name :", "{ "key" :" or an empty "{}" followed by
+// ';'.
+func (p *Parser) startsObjectLiteral() bool {
+	if p.current+2 >= len(p.tokens) {
+		return false
+	}
+	next, after := p.tokens[p.current+1], p.tokens[p.current+2]
+	if (next.Type == token.IDENTIFIER || next.Type == token.STRING) && after.Type == token.COLON {
+		return true
+	}
+	return next.Type == token.RIGHT_BRACE && after.Type == token.SEMICOLON
+}
+
 func (p *Parser) objectLiteral() (ast.Expr, error) {
+	line := p.previous().Line
 	properties := make(map[string]ast.Expr)
 
 	for !p.check(token.RIGHT_BRACE) && !p.isAtEnd() {
-		propName, err := p.consume(token.IDENTIFIER, "Expect property name. Must be a string.")
-		if err != nil {
-			return nil, err
+		// A key is either a bare name or a string literal, which allows keys
+		// with spaces or that are reserved words
+		var key string
+		if p.match(token.STRING) {
+			key = utils.NormalizeName(p.previous().Literal.(string))
+		} else {
+			propName, err := p.consume(token.IDENTIFIER, "Expect property name or string key.")
+			if err != nil {
+				return nil, err
+			}
+			key = propName.Lexeme
 		}
 
-		// Expect a colon `:` after the property name
-		_, err = p.consume(token.COLON, "Expect ':' after property name.")
+		_, err := p.consume(token.COLON, "Expect ':' after property name.")
 		if err != nil {
 			return nil, err
 		}
@@ -831,9 +1405,10 @@ func (p *Parser) objectLiteral() (ast.Expr, error) {
 
 		// fmt.Printf("%#v ---- %#v\n", propName, propValue)
 		// Store the property in the map
-		properties[propName.Lexeme] = propValue
+		properties[key] = propValue
 
-		// If there's no comma, break out of the loop
+		// If there's no comma, break out of the loop. A trailing comma ends
+		// the loop through the check on '}' above.
 		if !p.match(token.COMMA) {
 			break
 		}
@@ -844,23 +1419,24 @@ func (p *Parser) objectLiteral() (ast.Expr, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &ast.ObjectLiteral{Properties: properties}, nil
+	return &ast.ObjectLiteral{Properties: properties, Line: line}, nil
 }
 
 // New function to handle array literals
 func (p *Parser) arrayLiteral() (ast.Expr, error) {
+	line := p.previous().Line
 	elements := []ast.Expr{}
 
 	if !p.check(token.RIGHT_BRACKET) { // If the array is not empty
 		for {
-			element, err := p.expression()
+			element, err := p.spreadOrExpression()
 			if err != nil {
 				return nil, err
 			}
 			elements = append(elements, element)
 
-			// Check if there are more elements
-			if !p.match(token.COMMA) {
+			// Check if there are more elements, allowing a trailing comma
+			if !p.match(token.COMMA) || p.check(token.RIGHT_BRACKET) {
 				break
 			}
 		}
@@ -871,7 +1447,21 @@ func (p *Parser) arrayLiteral() (ast.Expr, error) {
 		return nil, err
 	}
 
-	return &ast.ArrayLiteral{Elements: elements}, nil
+	return &ast.ArrayLiteral{Elements: elements, Line: line}, nil
+}
+
+// spreadOrExpression parses an element of an argument list or array literal,
+// which may be prefixed with '...' to spread an array in place.
+func (p *Parser) spreadOrExpression() (ast.Expr, error) {
+	if p.match(token.ELLIPSIS) {
+		line := p.previous().Line
+		expr, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		return &ast.Spread{Expression: expr, Line: line}, nil
+	}
+	return p.expression()
 }
 
 func (p *Parser) match(types ...token.TokenType) bool {
@@ -896,6 +1486,46 @@ func (p *Parser) error(t token.Token, message string) error {
 	return fmt.Errorf(message)
 }
 
+// misplaced records an error for a statement that parsed correctly but is not
+// allowed where it appears. Parsing carries on without synchronizing.
+func (p *Parser) misplaced(keyword token.Token, message string) {
+	p.errors = append(p.errors, p.error(keyword, message))
+}
+
+// synchronize discards tokens until the start of the next statement: just
+// after a ';' or just before a keyword that begins a statement. A block met
+// along the way is discarded whole, and a '}' closing the enclosing block
+// stops it so the block can still end there.
+func (p *Parser) synchronize() {
+	depth := 0
+	if p.advance().Type == token.LEFT_BRACE {
+		depth++
+	}
+
+	for !p.isAtEnd() {
+		if depth == 0 && p.previous().Type == token.SEMICOLON {
+			return
+		}
+
+		switch p.peek().Type {
+		case token.LEFT_BRACE:
+			depth++
+		case token.RIGHT_BRACE:
+			if depth == 0 {
+				return
+			}
+			depth--
+		case token.CLASS, token.FUN, token.VAR, token.FOR, token.FOREACH, token.IF, token.WHILE,
+			token.SWITCH, token.TRY, token.THROW, token.PRINT, token.RETURN, token.BREAK, token.CONTINUE:
+			if depth == 0 {
+				return
+			}
+		}
+
+		p.advance()
+	}
+}
+
 func (p *Parser) check(tokenType token.TokenType) bool {
 	if p.isAtEnd() {
 		return false
@@ -903,6 +1533,15 @@ func (p *Parser) check(tokenType token.TokenType) bool {
 	return p.peek().Type == tokenType
 }
 
+// checkNext reports whether the token after the current one has the given
+// type.
+func (p *Parser) checkNext(tokenType token.TokenType) bool {
+	if p.current+1 >= len(p.tokens) {
+		return false
+	}
+	return p.tokens[p.current+1].Type == tokenType
+}
+
 func (p *Parser) advance() token.Token {
 	if !p.isAtEnd() {
 		p.current++
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"io"
 	"os"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/ah-naf/borno/ast"
@@ -12,17 +14,17 @@ import (
 )
 
 // Helper function to scan and parse an input expression
-func scanAndParse(input string) ([]ast.Stmt, error) {
+func scanAndParse(input string) ([]ast.Stmt, []error) {
 	// Scan tokens from input using the lexer
 	inputRune := []rune(input)
 	scanner := lexer.NewScanner(inputRune)
-	tokens := scanner.ScanTokens()
+	tokens, _ := scanner.ScanTokens()
 
 	// Parse the tokens using the parser
 	p := parser.NewParser(tokens)
-	expr, err := p.Parse()
+	expr, errs := p.Parse()
 
-	return expr, err
+	return expr, errs
 }
 
 func CaptureStderr(f func()) string {
@@ -132,6 +134,24 @@ func TestParseGrammar(t *testing.T) {
 			expected:  "((-(group (1 + 2))) * (!(group (3 > 4))))",
 			expectErr: false,
 		},
+		{
+			name:      "Unary plus",
+			input:     "+5;",
+			expected:  "(+5)",
+			expectErr: false,
+		},
+		{
+			name:      "Unary plus after binary plus",
+			input:     "1 + +2;",
+			expected:  "(1 + (+2))",
+			expectErr: false,
+		},
+		{
+			name:      "Double negation",
+			input:     "- -5;",
+			expected:  "(-(-5))",
+			expectErr: false,
+		},
 		{
 			name:      "Complex bitwise operations",
 			input:     "(5 & 3) | (4 ^ 2);",
@@ -150,6 +170,18 @@ func TestParseGrammar(t *testing.T) {
 			expected:  "(((1 + (2 * (3 ** 2))) & 4) | (5 ^ 6))",
 			expectErr: false,
 		},
+		{
+			name:      "Power Is Right Associative",
+			input:     "2 ** 3 ** 2;",
+			expected:  "(2 ** (3 ** 2))",
+			expectErr: false,
+		},
+		{
+			name:      "Unary Minus Binds Tighter Than Power",
+			input:     "-2 ** 2;",
+			expected:  "((-2) ** 2)",
+			expectErr: false,
+		},
 		{
 			name:      "Variable Declaration",
 			input:     "ধরি a = 10;",
@@ -162,6 +194,90 @@ func TestParseGrammar(t *testing.T) {
 			expected:  "var a = 10\nvar b = 20\n",
 			expectErr: false,
 		},
+		{
+			name:      "Variable Declaration Without Initializer",
+			input:     "ধরি x;",
+			expected:  "var x",
+			expectErr: false,
+		},
+		{
+			name:      "Multiple Variable Declaration Without Initializer",
+			input:     "ধরি a, b = 2, c;",
+			expected:  "var a\nvar b = 2\nvar c\n",
+			expectErr: false,
+		},
+		{
+			name:      "Multiple Declaration With Multi-line Object First",
+			input:     "ধরি a = {x: [1,\n2]}, b = 5;",
+			expected:  "var a = {x: [1, 2]}\nvar b = 5\n",
+			expectErr: false,
+		},
+		{
+			name:      "Multiple Declaration Mixing Scalars And Multi-line Literals",
+			input:     "ধরি a = 1, b = [\n1,\n2\n],\nc = {\ny: 2\n}, d;",
+			expected:  "var a = 1\nvar b = [1, 2]\nvar c = {y: 2}\nvar d\n",
+			expectErr: false,
+		},
+		{
+			name:      "Multiple Declaration With Multi-line Literal Missing Semicolon",
+			input:     "ধরি a = [1,\n2], b = 3\nদেখাও b;",
+			expected:  ``,
+			expectErr: true,
+		},
+		{
+			name:      "Array Pattern Declaration",
+			input:     "ধরি [_, b] = arr;",
+			expected:  "var [_, b] = arr",
+			expectErr: false,
+		},
+		{
+			name:      "Array Pattern Without Initializer",
+			input:     "ধরি [a, b];",
+			expected:  ``,
+			expectErr: true,
+		},
+		{
+			name:      "Array Pattern With A Non-Name",
+			input:     "ধরি [a, 1] = arr;",
+			expected:  ``,
+			expectErr: true,
+		},
+		{
+			name:      "Variable Declaration Without Initializer Missing Semicolon",
+			input:     "ধরি x\nদেখাও x;",
+			expected:  ``,
+			expectErr: true,
+		},
+		{
+			name:      "Try Catch Finally",
+			input:     "চেষ্টা { নিক্ষেপ 1; } ধরো (e) { দেখাও e; } অবশেষে { দেখাও 2; }",
+			expected:  "try {\nthrow 1\n} catch (e) {\n(print e)\n} finally {\n(print 2)\n}",
+			expectErr: false,
+		},
+		{
+			name:      "Try Finally",
+			input:     "চেষ্টা { } অবশেষে { }",
+			expected:  "try {\n} finally {\n}",
+			expectErr: false,
+		},
+		{
+			name:      "Try Without Catch Or Finally",
+			input:     "চেষ্টা { } দেখাও 1;",
+			expected:  "",
+			expectErr: true,
+		},
+		{
+			name:      "Catch Without Variable",
+			input:     "চেষ্টা { } ধরো { }",
+			expected:  "",
+			expectErr: true,
+		},
+		{
+			name:      "Throw Missing Semicolon",
+			input:     "নিক্ষেপ 1",
+			expected:  "",
+			expectErr: true,
+		},
 		{
 			name:      "Variable Assignment",
 			input:     "a = 10;",
@@ -347,6 +463,20 @@ continue
 }`,
 			expectErr: false,
 		},
+		{
+			name:  "For Loop With Two Increments",
+			input: `ফর (ধরি i = 0; i < j; i = i + 1, j = j - 1) { দেখাও i; }`,
+			expected: `for (var i = 0; (i < j); (i = (i + 1)), (j = (j - 1))) {
+(print i)
+}`,
+			expectErr: false,
+		},
+		{
+			name:      "For Loop With A Trailing Comma In The Increment",
+			input:     `ফর (ধরি i = 0; i < 10; i = i + 1,) { দেখাও i; }`,
+			expected:  "",
+			expectErr: true,
+		},
 		{
 			name:  "For Loop Without All Clauses",
 			input: `ফর (;;) { দেখাও "infinite"; }`,
@@ -379,7 +509,7 @@ continue
 		},
 		{
 			name:      "Function Call Error",
-			input:     `add(a,);`,
+			input:     `add(, a);`,
 			expected:  ``,
 			expectErr: true,
 		},
@@ -406,11 +536,87 @@ continue
 			expectErr: false,
 		},
 		{
-			name:      "Invalid Function Call with Trailing Comma",
+			name:      "Function Call with Trailing Comma",
 			input:     `add(5,);`,
+			expected:  `add(5)`,
+			expectErr: false,
+		},
+		{
+			name:      "Multi-line Function Call with Trailing Comma",
+			input:     "add(\n5,\n10,\n);",
+			expected:  `add(5, 10)`,
+			expectErr: false,
+		},
+		{
+			name:      "Invalid Function Call with Only a Comma",
+			input:     `add(,);`,
+			expected:  "",
+			expectErr: true,
+		},
+		{
+			name:      "Invalid Function Call with Two Trailing Commas",
+			input:     `add(5,,);`,
+			expected:  "",
+			expectErr: true,
+		},
+		{
+			name: "Function with Trailing Comma in Parameters",
+			input: `ফাংশন add(a, b,) {
+ফেরত a + b;
+}`,
+			expected: `fun add(a, b) {
+return (a + b)
+}`,
+			expectErr: false,
+		},
+		{
+			name:      "Invalid Function with Only a Comma in Parameters",
+			input:     `ফাংশন f(,) {}`,
 			expected:  "",
 			expectErr: true,
 		},
+		{
+			name:      "Arrow Function Argument",
+			input:     `ম্যাপ(a, ফাংশন(x) => x + 1);`,
+			expected:  `ম্যাপ(a, fun(x) => (x + 1))`,
+			expectErr: false,
+		},
+		{
+			name:      "Arrow Function In Declaration",
+			input:     `ধরি add = ফাংশন(a, b,) => a + b;`,
+			expected:  `var add = fun(a, b) => (a + b)`,
+			expectErr: false,
+		},
+		{
+			name:      "Arrow Function Without Parameters",
+			input:     `ফাংশন() => 1;`,
+			expected:  `fun() => 1`,
+			expectErr: false,
+		},
+		{
+			name:      "Anonymous Function With Block Body",
+			input:     `ধরি f = ফাংশন(x) { ফেরত x; };`,
+			expected:  "var f = fun(x) {\nreturn x\n}",
+			expectErr: false,
+		},
+		{
+			name:      "Anonymous Function As Object Property",
+			input:     `ধরি o = {greet: ফাংশন() { দেখাও "hi"; }};`,
+			expected:  "var o = {greet: fun() {\n(print hi)\n}}",
+			expectErr: false,
+		},
+		{
+			name:      "Anonymous Function Without Body",
+			input:     `ধরি f = ফাংশন(x);`,
+			expected:  ``,
+			expectErr: true,
+		},
+		{
+			name:      "Arrow Function Body Must Be An Expression",
+			input:     `ধরি f = ফাংশন(x) => ফেরত x;`,
+			expected:  ``,
+			expectErr: true,
+		},
 		{
 			name:      "Nested Function Call",
 			input:     `add(multiply(2, 3), 5);`,
@@ -419,8 +625,80 @@ continue
 		},
 		{
 			name:      "Return statemetn",
+			input:     "ফাংশন f() { ফেরত a; }",
+			expected:  "fun f() {\nreturn a\n}",
+			expectErr: false,
+		},
+		{
+			name:      "Return Without Value",
+			input:     "ফাংশন f() { ফেরত; }",
+			expected:  "fun f() {\nreturn\n}",
+			expectErr: false,
+		},
+		{
+			name:      "Return Outside Function",
 			input:     "ফেরত a;",
-			expected:  "return a",
+			expected:  ``,
+			expectErr: true,
+		},
+		{
+			name:      "Return In Loop Outside Function",
+			input:     "যতক্ষণ (সত্য) { ফেরত; }",
+			expected:  ``,
+			expectErr: true,
+		},
+		{
+			name:      "Break Outside Loop",
+			input:     "যদি (মিথ্যা) { থামো; }",
+			expected:  ``,
+			expectErr: true,
+		},
+		{
+			name:      "Continue Outside Loop",
+			input:     "চালিয়ে_যাও;",
+			expected:  ``,
+			expectErr: true,
+		},
+		{
+			name:      "Continue In Switch Outside Loop",
+			input:     "সুইচ (1) { ক্ষেত্রে 1: চালিয়ে_যাও; }",
+			expected:  ``,
+			expectErr: true,
+		},
+		{
+			name:      "Multi-value Case",
+			input:     "সুইচ (x) { ক্ষেত্রে 1, 2, 3: থামো; }",
+			expected:  "switch (x) {\ncase 1, 2, 3:\nbreak\n}",
+			expectErr: false,
+		},
+		{
+			name:      "Range Case",
+			input:     "সুইচ (x) { ক্ষেত্রে 1..10, 20: থামো; }",
+			expected:  "switch (x) {\ncase 1..10, 20:\nbreak\n}",
+			expectErr: false,
+		},
+		{
+			name:      "Range Case Missing Upper Bound",
+			input:     "সুইচ (x) { ক্ষেত্রে 1..: থামো; }",
+			expected:  "",
+			expectErr: true,
+		},
+		{
+			name:      "Break In Switch",
+			input:     "সুইচ (1) { ক্ষেত্রে 1: থামো; }",
+			expected:  "switch (1) {\ncase 1:\nbreak\n}",
+			expectErr: false,
+		},
+		{
+			name:      "Break In Function Inside Loop",
+			input:     "যতক্ষণ (সত্য) { ফাংশন f() { থামো; } }",
+			expected:  ``,
+			expectErr: true,
+		},
+		{
+			name:      "Continue In Nested Loop Body",
+			input:     "যতক্ষণ (সত্য) { যদি (সত্য) চালিয়ে_যাও; }",
+			expected:  "while (true){\nif (true)continue\n}",
 			expectErr: false,
 		},
 		{
@@ -465,6 +743,24 @@ continue
 			expected:  "",
 			expectErr: true,
 		},
+		{
+			name:      "Array Literal with Trailing Comma",
+			input:     "ধরি arr = [1, 2,];",
+			expected:  "var arr = [1, 2]",
+			expectErr: false,
+		},
+		{
+			name:      "Multi-line Array Literal with Trailing Comma",
+			input:     "[\n1,\n2,\n];",
+			expected:  "[1, 2]",
+			expectErr: false,
+		},
+		{
+			name:      "Invalid Array Literal with Only a Comma",
+			input:     "[,];",
+			expected:  "",
+			expectErr: true,
+		},
 		{
 			name:      "Array Assignment",
 			input:     "arr[0] = 10;",
@@ -477,12 +773,234 @@ continue
 			expected:  "arr[0]()",
 			expectErr: false,
 		},
+		{
+			name:      "Multi-line Array Literal In Print",
+			input:     "দেখাও [\n1,\n2\n];",
+			expected:  "(print [1, 2])",
+			expectErr: false,
+		},
+		{
+			name:      "Multi-line Array Literal Statement",
+			input:     "[\n1,\n2\n];",
+			expected:  "[1, 2]",
+			expectErr: false,
+		},
+		{
+			name:      "Multi-line Expression In Declaration",
+			input:     "ধরি a = 1 +\n2;",
+			expected:  "var a = (1 + 2)",
+			expectErr: false,
+		},
+		{
+			name:      "Object Literal Statement",
+			input:     "{a: 1};",
+			expected:  "{a: 1}",
+			expectErr: false,
+		},
+		{
+			name:      "Object Literal with Trailing Comma",
+			input:     "{\na: 1,\n};",
+			expected:  "{a: 1}",
+			expectErr: false,
+		},
+		{
+			name:      "Invalid Object Literal with Only a Comma",
+			input:     "{,};",
+			expected:  "",
+			expectErr: true,
+		},
+		{
+			name:      "Multi-line Object Literal Statement",
+			input:     "{\na: [\n1\n]\n};",
+			expected:  "{a: [1]}",
+			expectErr: false,
+		},
+		{
+			name:      "Empty Object Literal Statement",
+			input:     "{};",
+			expected:  "{}",
+			expectErr: false,
+		},
+		{
+			name:      "Block Is Not Object Literal",
+			input:     "{ a; }",
+			expected:  "{\na\n}",
+			expectErr: false,
+		},
+		{
+			name:      "Print Single Value",
+			input:     `দেখাও a;`,
+			expected:  `(print a)`,
+			expectErr: false,
+		},
+		{
+			name:      "Print Multiple Values",
+			input:     `দেখাও a, "b", 1 + 2;`,
+			expected:  `(print a, b, (1 + 2))`,
+			expectErr: false,
+		},
+		{
+			name:      "Print Trailing Comma",
+			input:     `দেখাও a, ;`,
+			expected:  ``,
+			expectErr: true,
+		},
+		{
+			name:      "Print Missing Semicolon",
+			input:     `দেখাও a`,
+			expected:  ``,
+			expectErr: true,
+		},
+		{
+			name:      "Print Missing Semicolon Before Next Statement",
+			input:     "দেখাও a\nদেখাও b;",
+			expected:  ``,
+			expectErr: true,
+		},
+		{
+			name:      "Expression Missing Semicolon",
+			input:     `a + 1`,
+			expected:  ``,
+			expectErr: true,
+		},
+		{
+			name:      "Call Missing Semicolon",
+			input:     `f(1) g(2);`,
+			expected:  ``,
+			expectErr: true,
+		},
+		{
+			name:      "Return Missing Semicolon",
+			input:     `ফেরত 5`,
+			expected:  ``,
+			expectErr: true,
+		},
+		{
+			name:      "Bare Return Missing Semicolon",
+			input:     `ফাংশন f() { ফেরত }`,
+			expected:  ``,
+			expectErr: true,
+		},
+		{
+			name:      "Block Missing Closing Brace",
+			input:     `{ দেখাও 1;`,
+			expected:  ``,
+			expectErr: true,
+		},
+		{
+			name:      "Switch Statement",
+			input:     `সুইচ (x) { ক্ষেত্রে 1: দেখাও "এক"; থামো; নইলে: দেখাও x; }`,
+			expected:  "switch (x) {\ncase 1:\n(print এক)\nbreak\ndefault:\n(print x)\n}",
+			expectErr: false,
+		},
+		{
+			name:      "Switch Without Cases",
+			input:     `সুইচ (x) {}`,
+			expected:  "switch (x) {\n}",
+			expectErr: false,
+		},
+		{
+			name:      "Switch Duplicate Default",
+			input:     `সুইচ (x) { নইলে: দেখাও 1; নইলে: দেখাও 2; }`,
+			expected:  ``,
+			expectErr: true,
+		},
+		{
+			name:      "Switch Statement Outside Case",
+			input:     `সুইচ (x) { দেখাও 1; }`,
+			expected:  ``,
+			expectErr: true,
+		},
+		{
+			name:      "Switch Case Missing Colon",
+			input:     `সুইচ (x) { ক্ষেত্রে 1 দেখাও 1; }`,
+			expected:  ``,
+			expectErr: true,
+		},
+		{
+			name:      "Foreach Statement",
+			input:     `প্রত্যেক (ch ইন "হ্যালো") { দেখাও ch; }`,
+			expected:  "foreach (ch in হ্যালো) {\n(print ch)\n}",
+			expectErr: false,
+		},
+		{
+			name:      "Foreach Missing In",
+			input:     `প্রত্যেক (ch "হ্যালো") দেখাও ch;`,
+			expected:  ``,
+			expectErr: true,
+		},
+		{
+			name:      "Foreach Missing Variable",
+			input:     `প্রত্যেক ("হ্যালো" ইন xs) দেখাও 1;`,
+			expected:  ``,
+			expectErr: true,
+		},
+		{
+			name:      "Optional Property Access",
+			input:     "a?.b.c;",
+			expected:  "a?.b.c",
+			expectErr: false,
+		},
+		{
+			name:      "Optional Index Access",
+			input:     "a?.[1]?.b;",
+			expected:  "a?.[1]?.b",
+			expectErr: false,
+		},
+		{
+			name:      "Optional Access Missing Name",
+			input:     "a?.;",
+			expected:  ``,
+			expectErr: true,
+		},
+		{
+			name:      "Optional Access Is Not Assignable",
+			input:     "a?.b = 1;",
+			expected:  ``,
+			expectErr: true,
+		},
+		{
+			name:      "Spread Call Arguments",
+			input:     `f(...args, 1);`,
+			expected:  `f(...args, 1)`,
+			expectErr: false,
+		},
+		{
+			name:      "Spread Array Elements",
+			input:     `[...a, ...b, 5];`,
+			expected:  `[...a, ...b, 5]`,
+			expectErr: false,
+		},
+		{
+			name:      "Spread Outside Call or Array",
+			input:     `...a;`,
+			expected:  ``,
+			expectErr: true,
+		},
 		{
 			name:      "Object Literal",
 			input:     `ধরি obj = {name: "Alice", age: 30, height: 5.9};`,
 			expected:  `var obj = {name: Alice, age: 30, height: 5.9}`,
 			expectErr: false,
 		},
+		{
+			name:      "Object with String Keys",
+			input:     `ধরি obj = {"full name": "Alice"};`,
+			expected:  `var obj = {full name: Alice}`,
+			expectErr: false,
+		},
+		{
+			name:      "Object with Reserved Word Key",
+			input:     `ধরি obj = {"যদি": 5};`,
+			expected:  `var obj = {যদি: 5}`,
+			expectErr: false,
+		},
+		{
+			name:      "Object Literal Statement with String Key",
+			input:     `{"class": 5};`,
+			expected:  `{class: 5}`,
+			expectErr: false,
+		},
 		{
 			name:      "Object with Numeric Keys",
 			input:     `ধরি obj = {1: "one", 2: "two"};`,
@@ -543,11 +1061,11 @@ continue
 		t.Run(tt.name, func(t *testing.T) {
 			output := ""
 			captured := CaptureStderr(func() {
-				expr, err := scanAndParse(tt.input)
-				if err != nil {
+				expr, errs := scanAndParse(tt.input)
+				for _, err := range errs {
 					os.Stderr.Write([]byte(err.Error() + "\n"))
 				}
-				if len(expr) == 0 {
+				if len(errs) > 0 || len(expr) == 0 {
 					return
 				}
 				output = expr[0].String()
@@ -565,3 +1083,157 @@ continue
 		})
 	}
 }
+
+func TestParseErrorRecovery(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		statements []string
+		errors     []string
+	}{
+		{
+			name:       "No errors",
+			input:      "ধরি a = 1; দেখাও a;",
+			statements: []string{"var a = 1", "(print a)"},
+			errors:     nil,
+		},
+		{
+			name:       "Two independent errors",
+			input:      "ধরি = 1; দেখাও 2; যদি সত্য { দেখাও 3; } দেখাও 4;",
+			statements: []string{"(print 2)", "(print 4)"},
+			errors: []string{
+				"[line 1] Error at '=': Expect variable name.",
+				"[line 1] Error at 'সত্য': Expect '(' after 'if'.",
+			},
+		},
+		{
+			name:       "Error inside a block",
+			input:      "{ ধরি a = ; দেখাও 1; } দেখাও 2;",
+			statements: []string{"{\n(print 1)\n}", "(print 2)"},
+			errors: []string{
+				"[line 1] Error at ';': Unexpected token. Expect expression.",
+			},
+		},
+		{
+			name:       "Error before the end of a block",
+			input:      "{ দেখাও (1 2 } দেখাও 3;",
+			statements: []string{"{\n}", "(print 3)"},
+			errors: []string{
+				"[line 1] Error at '2': Expect ')' after expression.",
+			},
+		},
+		{
+			name:       "Errors on separate lines",
+			input:      "add(1,,);\nদেখাও 5;\narr[;",
+			statements: []string{"(print 5)"},
+			errors: []string{
+				"[line 1] Error at ',': Unexpected token. Expect expression.",
+				"[line 3] Error at ';': Unexpected token. Expect expression.",
+			},
+		},
+		{
+			name:       "For header missing the initializer semicolon",
+			input:      "ফর (ধরি i=0 i<5; i=i+1) { দেখাও i; }\nদেখাও 1;",
+			statements: []string{"(print 1)"},
+			errors: []string{
+				"[line 1] Error at 'i': Expect ';' after loop initializer.",
+			},
+		},
+		{
+			name:       "For header missing the expression initializer semicolon",
+			input:      "ফর (i=0 i<5; i=i+1) { দেখাও i; }\nদেখাও 1;",
+			statements: []string{"(print 1)"},
+			errors: []string{
+				"[line 1] Error at 'i': Expect ';' after loop initializer.",
+			},
+		},
+		{
+			name:       "For header missing the condition semicolon",
+			input:      "ফর (ধরি i=0; i<5 i=i+1) { যদি (i) { দেখাও i; } }\nদেখাও 1;",
+			statements: []string{"(print 1)"},
+			errors: []string{
+				"[line 1] Error at 'i': Expect ';' after loop condition.",
+			},
+		},
+		{
+			name:       "For header missing the closing parenthesis",
+			input:      "ফর (ধরি i=0; i<5; i=i+1 { দেখাও i; }\nদেখাও 1;",
+			statements: []string{"(print 1)"},
+			errors: []string{
+				"[line 1] Error at '{': Expect ')' after for clauses.",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var statements []ast.Stmt
+			var errs []error
+			captured := CaptureStderr(func() {
+				statements, errs = scanAndParse(tt.input)
+			})
+
+			if len(statements) != len(tt.statements) {
+				t.Fatalf("Expected %d statements, but got %d: %v", len(tt.statements), len(statements), statements)
+			}
+			for i, expected := range tt.statements {
+				if statements[i].String() != expected {
+					t.Errorf("Statement %d: expected %q, but got %q", i, expected, statements[i].String())
+				}
+			}
+
+			if len(errs) != len(tt.errors) {
+				t.Fatalf("Expected %d errors, but got %d: %v", len(tt.errors), len(errs), errs)
+			}
+			reported := strings.Split(strings.TrimSpace(captured), "\n")
+			for i, expected := range tt.errors {
+				if reported[i] != expected {
+					t.Errorf("Error %d: expected %q, but got %q", i, expected, reported[i])
+				}
+			}
+		})
+	}
+}
+
+// nodeLine reads the Line field every AST node carries.
+func nodeLine(node ast.Expr) int64 {
+	return reflect.ValueOf(node).Elem().FieldByName("Line").Int()
+}
+
+func TestNodesRecordTheirLine(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		expression func(ast.Stmt) ast.Expr
+	}{
+		{"Expression statement", "\n\nx;", nil},
+		{"Print", "\n\nদেখাও 1;", nil},
+		{"Variable list", "\n\nধরি a = 1, b = 2;", nil},
+		{"Block", "\n\n{ x; }", nil},
+		{"If", "\n\nযদি (x) { }", nil},
+		{"Function", "\n\nফাংশন f() { }", nil},
+		{"Throw", "\n\nনিক্ষেপ 1;", nil},
+		{"Return", "ফাংশন f() {\n\nফেরত 1; }", func(s ast.Stmt) ast.Expr { return s.(*ast.FunctionStmt).Body[0] }},
+		{"Call", "\n\nf(1,\n2);", func(s ast.Stmt) ast.Expr { return s.(*ast.ExpressionStatement).Expression }},
+		{"Logical", "\n\nx && y;", func(s ast.Stmt) ast.Expr { return s.(*ast.ExpressionStatement).Expression }},
+		{"Object literal", "\n\nধরি o = {a: 1};", func(s ast.Stmt) ast.Expr { return s.(*ast.VarStmt).Initializer }},
+		{"Array literal", "\n\nধরি a = [1];", func(s ast.Stmt) ast.Expr { return s.(*ast.VarStmt).Initializer }},
+		{"Anonymous function", "\n\nধরি f = ফাংশন() => 1;", func(s ast.Stmt) ast.Expr { return s.(*ast.VarStmt).Initializer }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statements, errs := scanAndParse(tt.input)
+			if len(errs) > 0 || len(statements) != 1 {
+				t.Fatalf("Expected one statement, got %v with errors %v", statements, errs)
+			}
+			var node ast.Expr = statements[0]
+			if tt.expression != nil {
+				node = tt.expression(statements[0])
+			}
+			if line := nodeLine(node); line != 3 {
+				t.Fatalf("Expected %T on line 3, got line %d", node, line)
+			}
+		})
+	}
+}
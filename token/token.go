@@ -40,6 +40,12 @@ const (
 	LESS
 	LESS_EQUAL
 	RIGHT_SHIFT
+	QUESTION_DOT
+	ARROW
+	DOT_DOT
+
+	// Three character tokens
+	ELLIPSIS
 
 	// Literals
 	IDENTIFIER
@@ -63,15 +69,111 @@ const (
 	TRUE
 	VAR
 	WHILE
+	SWITCH
+	CASE
+	DEFAULT
+	FOREACH
+	IN
+	TRY
+	CATCH
+	FINALLY
+	THROW
 
 	EOF
 )
 
+var tokenTypeNames = [...]string{
+	LEFT_PAREN:    "LEFT_PAREN",
+	RIGHT_PAREN:   "RIGHT_PAREN",
+	LEFT_BRACE:    "LEFT_BRACE",
+	RIGHT_BRACE:   "RIGHT_BRACE",
+	LEFT_BRACKET:  "LEFT_BRACKET",
+	RIGHT_BRACKET: "RIGHT_BRACKET",
+	COMMA:         "COMMA",
+	DOT:           "DOT",
+	MINUS:         "MINUS",
+	PLUS:          "PLUS",
+	SEMICOLON:     "SEMICOLON",
+	COLON:         "COLON",
+	SLASH:         "SLASH",
+	STAR:          "STAR",
+	AND:           "AND",
+	OR:            "OR",
+	XOR:           "XOR",
+	POWER:         "POWER",
+	NOT:           "NOT",
+	MODULO:        "MODULO",
+	BANG:          "BANG",
+	BANG_EQUAL:    "BANG_EQUAL",
+	EQUAL:         "EQUAL",
+	EQUAL_EQUAL:   "EQUAL_EQUAL",
+	GREATER:       "GREATER",
+	GREATER_EQUAL: "GREATER_EQUAL",
+	LEFT_SHIFT:    "LEFT_SHIFT",
+	LESS:          "LESS",
+	LESS_EQUAL:    "LESS_EQUAL",
+	RIGHT_SHIFT:   "RIGHT_SHIFT",
+	QUESTION_DOT:  "QUESTION_DOT",
+	ARROW:         "ARROW",
+	DOT_DOT:       "DOT_DOT",
+	ELLIPSIS:      "ELLIPSIS",
+	IDENTIFIER:    "IDENTIFIER",
+	STRING:        "STRING",
+	NUMBER:        "NUMBER",
+	BREAK:         "BREAK",
+	CONTINUE:      "CONTINUE",
+	LOGICAL_AND:   "LOGICAL_AND",
+	CLASS:         "CLASS",
+	ELSE:          "ELSE",
+	FALSE:         "FALSE",
+	FUN:           "FUN",
+	FOR:           "FOR",
+	IF:            "IF",
+	NIL:           "NIL",
+	LOGICAL_OR:    "LOGICAL_OR",
+	PRINT:         "PRINT",
+	RETURN:        "RETURN",
+	TRUE:          "TRUE",
+	VAR:           "VAR",
+	WHILE:         "WHILE",
+	SWITCH:        "SWITCH",
+	CASE:          "CASE",
+	DEFAULT:       "DEFAULT",
+	FOREACH:       "FOREACH",
+	IN:            "IN",
+	TRY:           "TRY",
+	CATCH:         "CATCH",
+	FINALLY:       "FINALLY",
+	THROW:         "THROW",
+	EOF:           "EOF",
+}
+
+// String returns the name of the token type's constant, such as "PLUS".
+func (t TokenType) String() string {
+	if t >= 0 && int(t) < len(tokenTypeNames) && tokenTypeNames[t] != "" {
+		return tokenTypeNames[t]
+	}
+	return fmt.Sprintf("TokenType(%d)", int(t))
+}
+
 type Token struct {
 	Type    TokenType
 	Lexeme  string
 	Literal interface{}
 	Line    int
+
+	// LeadingComments holds the comments directly before this token. It is
+	// only filled in when the scanner runs with PreserveComments.
+	LeadingComments []Comment
+}
+
+// Comment is a source comment kept as trivia, including its // or /* */
+// markers. Line and Column give the position of its first character.
+type Comment struct {
+	Text   string
+	Block  bool
+	Line   int
+	Column int
 }
 
 // NewToken creates a new Token instance
@@ -0,0 +1,36 @@
+package token
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestTokenTypeString(t *testing.T) {
+	for tokenType := LEFT_PAREN; tokenType <= EOF; tokenType++ {
+		if name := tokenType.String(); strings.HasPrefix(name, "TokenType(") {
+			t.Errorf("Token type %d has no name", int(tokenType))
+		}
+	}
+
+	tests := []struct {
+		tokenType TokenType
+		expected  string
+	}{
+		{PLUS, "PLUS"},
+		{IDENTIFIER, "IDENTIFIER"},
+		{QUESTION_DOT, "QUESTION_DOT"},
+		{EOF, "EOF"},
+		{EOF + 1, fmt.Sprintf("TokenType(%d)", int(EOF)+1)},
+	}
+	for _, tt := range tests {
+		if got := tt.tokenType.String(); got != tt.expected {
+			t.Errorf("Expected %q, got %q", tt.expected, got)
+		}
+	}
+
+	tok := &Token{Type: PLUS, Lexeme: "+"}
+	if got := tok.String(); got != "PLUS + <nil>" {
+		t.Errorf("Expected the token to print its type by name, got %q", got)
+	}
+}
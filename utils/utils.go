@@ -6,11 +6,36 @@ import (
 	"strings"
 
 	"github.com/ah-naf/borno/token"
+	"golang.org/x/text/unicode/norm"
 )
 
+// MaxSafeInteger is 2^53, where float64 stops holding every integer: 2^53 + 1
+// already rounds to 2^53. Integers of a smaller magnitude are kept as float64;
+// the scanner and the interpreter both keep anything from 2^53 on as a
+// *big.Int, so a literal and a computed result always agree.
+const MaxSafeInteger = 1 << 53
+
 var HadError bool = false
 var HadRuntimeError bool = false
 
+// LastRuntimeError holds the most recent error reported through RuntimeError,
+// so callers embedding the interpreter can return it instead of reading stderr.
+var LastRuntimeError *RuntimeErrorInfo
+
+// TryDepth counts the try bodies currently executing. While it is positive,
+// RuntimeError records the error without printing it, so the interpreter can
+// throw it to the enclosing catch instead.
+var TryDepth int
+
+type RuntimeErrorInfo struct {
+	Line    int
+	Message string
+}
+
+func (e *RuntimeErrorInfo) Error() string {
+	return fmt.Sprintf("[line %d] %s", e.Line, e.Message)
+}
+
 func GlobalError(line int, message string) {
 	report(line, "", message)
 }
@@ -29,8 +54,11 @@ func report(line int, where, message string) {
 }
 
 func RuntimeError(token token.Token, message string) {
-	fmt.Fprintf(os.Stderr, "%s\n[line %d]\n", message, token.Line)
+	if TryDepth == 0 {
+		fmt.Fprintf(os.Stderr, "%s\n[line %d]\n", message, token.Line)
+	}
 	HadRuntimeError = true
+	LastRuntimeError = &RuntimeErrorInfo{Line: token.Line, Message: message}
 }
 
 func ConvertBanglaDigitsToASCII(input string) string {
@@ -49,3 +77,19 @@ func ConvertBanglaDigitsToASCII(input string) string {
 	}
 	return result.String()
 }
+
+// NormalizeName puts a name into Unicode NFC, so a letter such as য় typed as
+// one code point (U+09DF) and as য followed by a nukta name the same thing.
+func NormalizeName(name string) string {
+	return norm.NFC.String(name)
+}
+
+// NormalizeNames returns a copy of names with every key normalized by
+// NormalizeName, for tables of names written in Go source.
+func NormalizeNames[V any](names map[string]V) map[string]V {
+	normalized := make(map[string]V, len(names))
+	for name, value := range names {
+		normalized[NormalizeName(name)] = value
+	}
+	return normalized
+}